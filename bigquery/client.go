@@ -122,8 +122,8 @@ func (b *BigQueryClient) SaveRecord(records map[string][]*Record) error {
 			continue
 		}
 
-		// Check if we need to update the table schema for new tags
-		changed, err := schema.UpdateIfRequired(ctx, events[0].Tags, b.dataset())
+		// Check if we need to update the table schema for new tags or the severity
+		changed, err := schema.UpdateIfRequired(ctx, events, b.dataset())
 		if err != nil {
 			return errs.Wrap(err)
 		}
@@ -6,13 +6,18 @@ package bigquery
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/zeebo/errs/v2"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 
 	"storj.io/eventkit"
+	"storj.io/eventkit/destination"
 	"storj.io/eventkit/pb"
 )
 
@@ -24,9 +29,13 @@ type BigQueryDestination struct {
 
 	closedMu sync.Mutex
 	closed   bool
+
+	stats eventkit.StatsCounter
 }
 
 var _ eventkit.Destination = &BigQueryDestination{}
+var _ eventkit.StatsReporter = &BigQueryDestination{}
+var _ eventkit.Sender = &BigQueryDestination{}
 
 func NewBigQueryDestination(ctx context.Context, appName, project, dataset string, options ...option.ClientOption) (*BigQueryDestination, error) {
 	c, err := NewBigQueryClient(ctx, project, dataset, options...)
@@ -47,12 +56,21 @@ func NewBigQueryDestination(ctx context.Context, appName, project, dataset strin
 
 // Submit implements Destination.
 func (b *BigQueryDestination) Submit(events ...*eventkit.Event) {
+	err := b.Send(events...)
+	if err != nil {
+		mon.Counter("dropped_events").Inc(int64(len(events)))
+		fmt.Printf("WARN: Couldn't save eventkit record to BQ: %+v", err)
+	}
+}
+
+// Send implements eventkit.Sender. Errors which won't go away by retrying are marked with destination.Permanent.
+func (b *BigQueryDestination) Send(events ...*eventkit.Event) (err error) {
 	b.closedMu.Lock()
 	defer b.closedMu.Unlock()
 	if b.closed {
-		return
+		b.stats.Dropped(len(events))
+		return destination.Permanent(errs.Errorf("bigquery destination is closed"))
 	}
-	var err error
 	defer mon.Task()(nil)(&err)
 	records := map[string][]*Record{}
 	for _, event := range events {
@@ -78,16 +96,39 @@ func (b *BigQueryDestination) Submit(events ...*eventkit.Event) {
 			},
 			ReceivedAt: time.Now(),
 			Timestamp:  event.Timestamp,
+			ID:         event.ID.String(),
+			Severity:   event.Severity,
 			Tags:       tags,
 		})
 	}
 
 	err = b.client.SaveRecord(records)
 	if err != nil {
-		mon.Counter("dropped_events").Inc(int64(len(events)))
 		mon.Counter("submit_events_error").Inc(int64(len(events)))
-		fmt.Printf("WARN: Couldn't save eventkit record to BQ: %+v", err)
+		b.stats.Failed(len(events), err)
+		return classifyError(err)
 	}
+	b.stats.Sent(len(events))
+	return nil
+}
+
+// classifyError marks the errors of requests which are rejected by BigQuery, and therefore shouldn't be retried, as
+// permanent.
+func classifyError(err error) error {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.Code == http.StatusRequestTimeout, apiErr.Code == http.StatusTooManyRequests:
+		case apiErr.Code >= 400 && apiErr.Code < 500:
+			return destination.Permanent(err)
+		}
+	}
+	return err
+}
+
+// Stats implements eventkit.StatsReporter.
+func (b *BigQueryDestination) Stats() eventkit.Stats {
+	return b.stats.Snapshot(0)
 }
 
 func (b *BigQueryDestination) Run(ctx context.Context) {
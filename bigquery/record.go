@@ -20,6 +20,14 @@ type Record struct {
 	Timestamp  time.Time
 	Correction time.Duration
 
+	// ID is the unique ID of the event, used as the insert ID for
+	// deduplication by the BigQuery streaming API. It's empty when the
+	// event doesn't have an ID.
+	ID string
+
+	// Severity is stored in the severity column, unless it's unspecified.
+	Severity pb.Severity
+
 	Tags []*pb.Tag
 }
 
@@ -46,26 +54,14 @@ func (r *Record) ToJSON() ([]byte, error) {
 	fields["received_at"] = r.ReceivedAt.Format(time.RFC3339Nano)
 	fields["timestamp"] = r.Timestamp.Format(time.RFC3339Nano)
 	fields["correction"] = r.Correction.Nanoseconds()
+	if r.Severity != pb.Severity_UNSPECIFIED {
+		fields[severityField] = r.Severity.String()
+	}
 
 	// Add tag fields
 	for _, tag := range r.Tags {
-		field := tagFieldName(tag.Key)
-
-		switch v := tag.Value.(type) {
-		case *pb.Tag_Bool:
-			fields[field] = v.Bool
-		case *pb.Tag_Bytes:
-			fields[field] = v.Bytes
-		case *pb.Tag_Double:
-			fields[field] = v.Double
-		case *pb.Tag_DurationNs:
-			fields[field] = v.DurationNs
-		case *pb.Tag_Int64:
-			fields[field] = v.Int64
-		case *pb.Tag_String_:
-			fields[field] = string(v.String_)
-		case *pb.Tag_Timestamp:
-			fields[field] = time.Unix(v.Timestamp.Seconds, int64(v.Timestamp.Nanos)).Format(time.RFC3339Nano)
+		if value, ok := tagValue(tag, true); ok {
+			fields[tagFieldName(tag.Key)] = value
 		}
 	}
 
@@ -84,26 +80,56 @@ func (r *Record) Save() (map[string]bigquery.Value, string, error) {
 	fields["received_at"] = r.ReceivedAt
 	fields["timestamp"] = r.Timestamp
 	fields["correction"] = r.Correction
+	if r.Severity != pb.Severity_UNSPECIFIED {
+		fields[severityField] = r.Severity.String()
+	}
 	for _, tag := range r.Tags {
-		field := tagFieldName(tag.Key)
-
-		switch v := tag.Value.(type) {
-		case *pb.Tag_Bool:
-			fields[field] = v.Bool
-		case *pb.Tag_Bytes:
-			fields[field] = v.Bytes
-		case *pb.Tag_Double:
-			fields[field] = v.Double
-		case *pb.Tag_DurationNs:
-			fields[field] = v.DurationNs
-		case *pb.Tag_Int64:
-			fields[field] = v.Int64
-		case *pb.Tag_String_:
-			fields[field] = string(v.String_)
-		case *pb.Tag_Timestamp:
-			fields[field] = time.Unix(v.Timestamp.Seconds, int64(v.Timestamp.Nanos))
+		if value, ok := tagValue(tag, false); ok {
+			fields[tagFieldName(tag.Key)] = value
 		}
 	}
 
-	return fields, "", nil
+	return fields, r.ID, nil
+}
+
+// tagValue converts the value of the tag to the value of its field. Lists are converted to slices and maps to nested
+// records, like histograms. Timestamps are formatted as strings for JSON.
+func tagValue(tag *pb.Tag, jsonTime bool) (bigquery.Value, bool) {
+	switch v := asMap(tag).Value.(type) {
+	case *pb.Tag_Bool:
+		return v.Bool, true
+	case *pb.Tag_Bytes:
+		return v.Bytes, true
+	case *pb.Tag_Double:
+		return v.Double, true
+	case *pb.Tag_DurationNs:
+		return v.DurationNs, true
+	case *pb.Tag_Int64:
+		return v.Int64, true
+	case *pb.Tag_String_:
+		return string(v.String_), true
+	case *pb.Tag_Timestamp:
+		ts := time.Unix(v.Timestamp.Seconds, int64(v.Timestamp.Nanos))
+		if jsonTime {
+			return ts.Format(time.RFC3339Nano), true
+		}
+		return ts, true
+	case *pb.Tag_List:
+		values := make([]bigquery.Value, 0, len(v.List.GetValues()))
+		for _, value := range v.List.GetValues() {
+			if converted, ok := tagValue(value, jsonTime); ok {
+				values = append(values, converted)
+			}
+		}
+		return values, true
+	case *pb.Tag_Map:
+		record := make(map[string]bigquery.Value, len(v.Map.GetTags()))
+		for _, nested := range v.Map.GetTags() {
+			if converted, ok := tagValue(nested, jsonTime); ok {
+				record[fieldName(nested.Key)] = converted
+			}
+		}
+		return record, true
+	}
+	return nil, false
 }
@@ -2,7 +2,7 @@ package bigquery
 
 import (
 	"context"
-	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -20,6 +20,9 @@ import (
 	"storj.io/eventkit/pb"
 )
 
+// severityField is the column of the event severity, as the name of the pb.Severity.
+const severityField = "severity"
+
 // Schema represents the schema of a BigQuery table.
 type Schema struct {
 	name string
@@ -50,48 +53,11 @@ func NewSchema(ctx context.Context, ds *bigquery.Dataset, table string) (*Schema
 	return s, nil
 }
 
-func (s *Schema) UpdateIfRequired(ctx context.Context, tags []*pb.Tag, ds *bigquery.Dataset) (changed bool, err error) {
+func (s *Schema) UpdateIfRequired(ctx context.Context, records []*Record, ds *bigquery.Dataset) (changed bool, err error) {
 	s.schemeChangeLock.Lock()
 	defer s.schemeChangeLock.Unlock()
-	if !isTagMissing(s.tableMetadata.Schema, tags) {
-		return false, nil
-	}
-
-	schema := s.tableMetadata.Schema
-	origLen := len(schema)
-tagloop:
-	for _, tag := range tags {
-		for _, field := range s.tableMetadata.Schema {
-			if field.Name == tagFieldName(tag.Key) {
-				continue tagloop
-			}
-		}
-
-		// missing field
-		f := &bigquery.FieldSchema{
-			Name: tagFieldName(tag.Key),
-		}
-		switch tag.Value.(type) {
-		case *pb.Tag_Bool:
-			f.Type = bigquery.BooleanFieldType
-		case *pb.Tag_Bytes:
-			f.Type = bigquery.BytesFieldType
-		case *pb.Tag_Double:
-			f.Type = bigquery.FloatFieldType
-		case *pb.Tag_DurationNs:
-			f.Type = bigquery.IntegerFieldType
-		case *pb.Tag_Int64:
-			f.Type = bigquery.IntegerFieldType
-		case *pb.Tag_String_:
-			f.Type = bigquery.StringFieldType
-		case *pb.Tag_Timestamp:
-			f.Type = bigquery.TimestampFieldType
-		default:
-			continue tagloop
-		}
-		schema = append(schema, f)
-	}
-	if origLen == len(schema) {
+	schema, changed := mergeRecordFields(s.tableMetadata.Schema, records)
+	if !changed {
 		return false, nil
 	}
 
@@ -116,18 +82,49 @@ tagloop:
 
 // toMessageDescriptor converts the BigQuery table metadata to a protobuf message descriptor.
 func toMessageDescriptor(metadata *bigquery.TableMetadata) (protoreflect.MessageDescriptor, error) {
-	descriptorProto := descriptorpb.DescriptorProto{
-		Name:  proto.String("BqMessage"),
-		Field: make([]*descriptorpb.FieldDescriptorProto, 0, len(metadata.Schema)),
+	fileDescriptorProto := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String("message.proto"),
+		Package:     proto.String("dynamic"),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{descriptorProto("BqMessage", metadata.Schema)},
 	}
 
-	for ix, field := range metadata.Schema {
+	// Register the file descriptor
+	files := []*descriptorpb.FileDescriptorProto{fileDescriptorProto}
+
+	fileDesc, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{
+		File: files,
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	fileDescriptor, err := fileDesc.FindFileByPath("message.proto")
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	return fileDescriptor.Messages().ByName("BqMessage"), nil
+}
+
+// descriptorProto converts the BigQuery schema to a protobuf message. Repeated fields are repeated in the message too,
+// and records are nested messages. Fields with unknown types are skipped.
+func descriptorProto(name string, schema bigquery.Schema) *descriptorpb.DescriptorProto {
+	desc := &descriptorpb.DescriptorProto{
+		Name:  proto.String(name),
+		Field: make([]*descriptorpb.FieldDescriptorProto, 0, len(schema)),
+	}
+
+	for ix, field := range schema {
 		fieldDescriptor := &descriptorpb.FieldDescriptorProto{
 			Name:     proto.String(field.Name),
 			JsonName: proto.String(field.Name),
 			Number:   proto.Int32(int32(ix + 1)),
 			Options:  &descriptorpb.FieldOptions{},
 		}
+		if field.Repeated {
+			fieldDescriptor.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		}
 
 		switch field.Type {
 		case bigquery.StringFieldType:
@@ -143,37 +140,19 @@ func toMessageDescriptor(metadata *bigquery.TableMetadata) (protoreflect.Message
 		case bigquery.TimestampFieldType:
 			// Use int64 for timestamp representation (microseconds since epoch)
 			fieldDescriptor.Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+		case bigquery.RecordFieldType:
+			nested := descriptorProto("Record_"+field.Name, field.Schema)
+			desc.NestedType = append(desc.NestedType, nested)
+			fieldDescriptor.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			fieldDescriptor.TypeName = nested.Name
 		default:
 			// Skip unknown types
 			continue
 		}
 
-		descriptorProto.Field = append(descriptorProto.Field, fieldDescriptor)
-	}
-
-	fileDescriptorProto := &descriptorpb.FileDescriptorProto{
-		Name:        proto.String("message.proto"),
-		Package:     proto.String("dynamic"),
-		Syntax:      proto.String("proto3"),
-		MessageType: []*descriptorpb.DescriptorProto{&descriptorProto},
-	}
-
-	// Register the file descriptor
-	files := []*descriptorpb.FileDescriptorProto{fileDescriptorProto}
-
-	fileDesc, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{
-		File: files,
-	})
-	if err != nil {
-		return nil, errs.Wrap(err)
-	}
-
-	fileDescriptor, err := fileDesc.FindFileByPath("message.proto")
-	if err != nil {
-		return nil, errs.Wrap(err)
+		desc.Field = append(desc.Field, fieldDescriptor)
 	}
-
-	return fileDescriptor.Messages().ByName("BqMessage"), nil
+	return desc
 }
 
 // RecordToPB converts a Record to a protobuf message.
@@ -212,32 +191,12 @@ func (s *Schema) RecordToPB(record *Record) ([]byte, error) {
 	if field := s.messageDescriptor.Fields().ByName("correction"); field != nil {
 		msg.Set(field, protoreflect.ValueOfInt64(record.Correction.Nanoseconds()))
 	}
-
-	for _, tag := range record.Tags {
-		fieldName := tagFieldName(tag.Key)
-		if field := s.messageDescriptor.Fields().ByName(protoreflect.Name(fieldName)); field != nil {
-			switch v := tag.Value.(type) {
-			case *pb.Tag_Bool:
-				msg.Set(field, protoreflect.ValueOfBool(v.Bool))
-			case *pb.Tag_Bytes:
-				msg.Set(field, protoreflect.ValueOfBytes(v.Bytes))
-			case *pb.Tag_Double:
-				msg.Set(field, protoreflect.ValueOfFloat64(v.Double))
-			case *pb.Tag_DurationNs:
-				msg.Set(field, protoreflect.ValueOfInt64(v.DurationNs))
-			case *pb.Tag_Int64:
-				msg.Set(field, protoreflect.ValueOfInt64(v.Int64))
-			case *pb.Tag_String_:
-				msg.Set(field, protoreflect.ValueOfString(string(v.String_)))
-			case *pb.Tag_Timestamp:
-				if v.Timestamp != nil {
-					msg.Set(field, protoreflect.ValueOfInt64(v.Timestamp.Seconds*1_000_000+int64(v.Timestamp.Nanos/1000)))
-				}
-
-			}
-		}
+	if field := s.messageDescriptor.Fields().ByName(severityField); field != nil && record.Severity != pb.Severity_UNSPECIFIED {
+		msg.Set(field, protoreflect.ValueOfString(record.Severity.String()))
 	}
 
+	setTagFields(msg, record.Tags, tagFieldName)
+
 	data, err := proto.Marshal(msg)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -249,33 +208,7 @@ func (s *Schema) RecordToPB(record *Record) ([]byte, error) {
 func (s *Schema) PBDescriptor() *descriptorpb.DescriptorProto {
 	s.schemeChangeLock.Lock()
 	defer s.schemeChangeLock.Unlock()
-	desc := descriptorpb.DescriptorProto{
-		Name: proto.String("BqMessage"),
-	}
-	for ix, field := range s.tableMetadata.Schema {
-		fd := &descriptorpb.FieldDescriptorProto{
-			Name:     proto.String(field.Name),
-			JsonName: proto.String(field.Name),
-			Number:   proto.Int32(int32(ix + 1)),
-			Options:  &descriptorpb.FieldOptions{},
-		}
-		switch field.Type {
-		case bigquery.StringFieldType:
-			fd.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
-		case bigquery.IntegerFieldType, bigquery.TimestampFieldType:
-			fd.Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
-		case bigquery.FloatFieldType:
-			fd.Type = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum()
-		case bigquery.BooleanFieldType:
-			fd.Type = descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum()
-		case bigquery.BytesFieldType:
-			fd.Type = descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum()
-		default:
-			fmt.Println("Warning: unknown field type", field.Type)
-		}
-		desc.Field = append(desc.Field, fd)
-	}
-	return &desc
+	return descriptorProto("BqMessage", s.tableMetadata.Schema)
 }
 
 // LoadTableMetadata loads the table metadata from BigQuery.
@@ -329,6 +262,10 @@ func LoadTableMetadata(ctx context.Context, ds *bigquery.Dataset, table string)
 						Name: "correction",
 						Type: bigquery.IntegerFieldType,
 					},
+					{
+						Name: severityField,
+						Type: bigquery.StringFieldType,
+					},
 				},
 			})
 			if err != nil {
@@ -361,21 +298,216 @@ func TableName(scope []string, name string) string {
 }
 
 func tagFieldName(key string) string {
-	field := "tag_" + key
-	field = strings.ReplaceAll(field, "/", "_")
+	return "tag_" + fieldName(key)
+}
+
+// fieldName returns the name of the field of a nested tag in a record.
+func fieldName(key string) string {
+	field := strings.ReplaceAll(key, "/", "_")
 	field = strings.ReplaceAll(field, "-", "_")
 	return field
 }
 
 func isTagMissing(schema bigquery.Schema, tags []*pb.Tag) bool {
-tagloop:
+	_, missing := mergeTagFields(schema, tags, tagFieldName)
+	return missing
+}
+
+// mergeRecordFields returns the schema extended with the fields of the records which are missing from it. The tables
+// created before the severity column are extended with it only when a record has a severity.
+func mergeRecordFields(schema bigquery.Schema, records []*Record) (merged bigquery.Schema, changed bool) {
+	merged = schema
+	for _, record := range records {
+		var tagsChanged bool
+		merged, tagsChanged = mergeTagFields(merged, record.Tags, tagFieldName)
+		changed = changed || tagsChanged
+
+		if record.Severity != pb.Severity_UNSPECIFIED &&
+			!slices.ContainsFunc(merged, func(field *bigquery.FieldSchema) bool { return field.Name == severityField }) {
+			merged = append(slices.Clip(merged), &bigquery.FieldSchema{Name: severityField, Type: bigquery.StringFieldType})
+			changed = true
+		}
+	}
+	return merged, changed
+}
+
+// mergeTagFields returns the schema extended with the fields of the tags which are missing from it, including the
+// nested tags of maps. The schema itself is not modified.
+func mergeTagFields(schema bigquery.Schema, tags []*pb.Tag, name func(key string) string) (merged bigquery.Schema, changed bool) {
+	merged = schema
+	for _, tag := range tags {
+		tag = asMap(tag)
+		ix := slices.IndexFunc(merged, func(field *bigquery.FieldSchema) bool {
+			return field.Name == name(tag.Key)
+		})
+		if ix < 0 {
+			if f := tagFieldSchema(name(tag.Key), tag); f != nil {
+				merged = append(slices.Clip(merged), f)
+				changed = true
+			}
+			continue
+		}
+
+		// new keys of the maps are added to the existing records.
+		field := merged[ix]
+		if field.Type != bigquery.RecordFieldType {
+			continue
+		}
+		var nestedTags []*pb.Tag
+		switch v := tag.Value.(type) {
+		case *pb.Tag_Map:
+			nestedTags = v.Map.GetTags()
+		case *pb.Tag_List:
+			for _, value := range v.List.GetValues() {
+				if m, ok := value.Value.(*pb.Tag_Map); ok {
+					nestedTags = append(nestedTags, m.Map.GetTags()...)
+				}
+			}
+		}
+		if nested, nestedChanged := mergeTagFields(field.Schema, nestedTags, fieldName); nestedChanged {
+			updated := *field
+			updated.Schema = nested
+			merged = slices.Clone(merged)
+			merged[ix] = &updated
+			changed = true
+		}
+	}
+	return merged, changed
+}
+
+// asMap returns the histogram tags as map tags, which are stored in RECORD fields. Other tags are returned as is.
+func asMap(tag *pb.Tag) *pb.Tag {
+	if h, ok := tag.Value.(*pb.Tag_Histogram); ok {
+		return &pb.Tag{Key: tag.Key, Value: &pb.Tag_Map{Map: h.Histogram.AsMap()}}
+	}
+	return tag
+}
+
+// tagFieldSchema returns the schema of the field storing the tag. Lists are REPEATED fields with the type of their
+// first value, and maps are RECORD fields. It returns nil for the tags which can't be stored, like empty lists and
+// lists of lists. Histograms are RECORD fields with the buckets in a REPEATED RECORD field.
+func tagFieldSchema(name string, tag *pb.Tag) *bigquery.FieldSchema {
+	f := &bigquery.FieldSchema{
+		Name: name,
+	}
+	switch v := asMap(tag).Value.(type) {
+	case *pb.Tag_Bool:
+		f.Type = bigquery.BooleanFieldType
+	case *pb.Tag_Bytes:
+		f.Type = bigquery.BytesFieldType
+	case *pb.Tag_Double:
+		f.Type = bigquery.FloatFieldType
+	case *pb.Tag_DurationNs:
+		f.Type = bigquery.IntegerFieldType
+	case *pb.Tag_Int64:
+		f.Type = bigquery.IntegerFieldType
+	case *pb.Tag_String_:
+		f.Type = bigquery.StringFieldType
+	case *pb.Tag_Timestamp:
+		f.Type = bigquery.TimestampFieldType
+	case *pb.Tag_Map:
+		f.Type = bigquery.RecordFieldType
+		f.Schema, _ = mergeTagFields(nil, v.Map.GetTags(), fieldName)
+		if len(f.Schema) == 0 {
+			return nil
+		}
+	case *pb.Tag_List:
+		values := v.List.GetValues()
+		if len(values) == 0 {
+			return nil
+		}
+		if _, nested := values[0].Value.(*pb.Tag_List); nested {
+			return nil
+		}
+		element := tagFieldSchema(name, values[0])
+		if element == nil {
+			return nil
+		}
+		if element.Type == bigquery.RecordFieldType {
+			// all the keys of the maps.
+			for _, value := range values[1:] {
+				if m, ok := value.Value.(*pb.Tag_Map); ok {
+					element.Schema, _ = mergeTagFields(element.Schema, m.Map.GetTags(), fieldName)
+				}
+			}
+		}
+		f = element
+		f.Repeated = true
+	default:
+		return nil
+	}
+	return f
+}
+
+// setTagFields sets the fields of the message from the tags. The tags without a matching field are skipped.
+func setTagFields(msg *dynamicpb.Message, tags []*pb.Tag, name func(key string) string) {
+	fields := msg.Descriptor().Fields()
 	for _, tag := range tags {
-		for _, field := range schema {
-			if field.Name == tagFieldName(tag.Key) {
-				continue tagloop
+		tag = asMap(tag)
+		field := fields.ByName(protoreflect.Name(name(tag.Key)))
+		if field == nil {
+			continue
+		}
+		if field.IsList() {
+			list, ok := tag.Value.(*pb.Tag_List)
+			if !ok {
+				continue
+			}
+			values := msg.Mutable(field).List()
+			for _, value := range list.List.GetValues() {
+				if v, ok := fieldValue(field, value, values.NewElement); ok {
+					values.Append(v)
+				}
+			}
+			continue
+		}
+		if v, ok := fieldValue(field, tag, func() protoreflect.Value { return msg.NewField(field) }); ok {
+			msg.Set(field, v)
+		}
+	}
+}
+
+// fieldValue converts the value of the tag for the field. newMessage creates the value of a record field. Values which
+// don't match the kind of the field, for example elements of a mixed-type list, are skipped.
+func fieldValue(field protoreflect.FieldDescriptor, tag *pb.Tag, newMessage func() protoreflect.Value) (protoreflect.Value, bool) {
+	kind := field.Kind()
+	switch v := asMap(tag).Value.(type) {
+	case *pb.Tag_Bool:
+		if kind == protoreflect.BoolKind {
+			return protoreflect.ValueOfBool(v.Bool), true
+		}
+	case *pb.Tag_Bytes:
+		if kind == protoreflect.BytesKind {
+			return protoreflect.ValueOfBytes(v.Bytes), true
+		}
+	case *pb.Tag_Double:
+		if kind == protoreflect.DoubleKind {
+			return protoreflect.ValueOfFloat64(v.Double), true
+		}
+	case *pb.Tag_DurationNs:
+		if kind == protoreflect.Int64Kind {
+			return protoreflect.ValueOfInt64(v.DurationNs), true
+		}
+	case *pb.Tag_Int64:
+		if kind == protoreflect.Int64Kind {
+			return protoreflect.ValueOfInt64(v.Int64), true
+		}
+	case *pb.Tag_String_:
+		if kind == protoreflect.StringKind {
+			return protoreflect.ValueOfString(string(v.String_)), true
+		}
+	case *pb.Tag_Timestamp:
+		if v.Timestamp != nil && kind == protoreflect.Int64Kind {
+			return protoreflect.ValueOfInt64(v.Timestamp.Seconds*1_000_000 + int64(v.Timestamp.Nanos/1000)), true
+		}
+	case *pb.Tag_Map:
+		if kind == protoreflect.MessageKind {
+			nested := newMessage()
+			if msg, ok := nested.Message().(*dynamicpb.Message); ok {
+				setTagFields(msg, v.Map.GetTags(), fieldName)
+				return nested, true
 			}
 		}
-		return true
 	}
-	return false
+	return protoreflect.Value{}, false
 }
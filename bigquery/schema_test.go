@@ -0,0 +1,151 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bigquery
+
+import (
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"storj.io/eventkit"
+	"storj.io/eventkit/pb"
+)
+
+func TestSchemaStructuredTags(t *testing.T) {
+	tags := []*pb.Tag{
+		eventkit.String("name", "upload"),
+		eventkit.Strings("labels", []string{"a", "b"}),
+		eventkit.Map("user", eventkit.String("id", "u1"), eventkit.Int64("age", 42)),
+		eventkit.List("pieces",
+			eventkit.Map("", eventkit.Int64("num", 1)),
+			eventkit.Map("", eventkit.Int64("num", 2), eventkit.Bool("ok", true))),
+		eventkit.List("empty"),
+	}
+
+	schema, changed := mergeTagFields(nil, tags, tagFieldName)
+	require.True(t, changed)
+	require.Len(t, schema, 4)
+	require.Equal(t, &bigquery.FieldSchema{Name: "tag_labels", Type: bigquery.StringFieldType, Repeated: true}, schema[1])
+	require.Equal(t, bigquery.RecordFieldType, schema[2].Type)
+	require.Len(t, schema[2].Schema, 2)
+	require.True(t, schema[3].Repeated)
+	require.Equal(t, []string{"num", "ok"}, []string{schema[3].Schema[0].Name, schema[3].Schema[1].Name})
+
+	_, changed = mergeTagFields(schema, tags, tagFieldName)
+	require.False(t, changed)
+	extended, changed := mergeTagFields(schema, []*pb.Tag{eventkit.Map("user", eventkit.String("email", "x"))}, tagFieldName)
+	require.True(t, changed)
+	require.Len(t, extended[2].Schema, 3)
+	require.Len(t, schema[2].Schema, 2, "the original schema is not modified")
+
+	metadata := &bigquery.TableMetadata{Schema: schema}
+	descriptor, err := toMessageDescriptor(metadata)
+	require.NoError(t, err)
+	s := &Schema{schemeChangeLock: &sync.Mutex{}, tableMetadata: metadata, messageDescriptor: descriptor}
+
+	data, err := s.RecordToPB(&Record{Tags: tags})
+	require.NoError(t, err)
+
+	msg := dynamicpb.NewMessage(descriptor)
+	require.NoError(t, proto.Unmarshal(data, msg))
+	field := func(m protoreflect.Message, name string) protoreflect.Value {
+		return m.Get(m.Descriptor().Fields().ByName(protoreflect.Name(name)))
+	}
+
+	labels := field(msg, "tag_labels").List()
+	require.Equal(t, 2, labels.Len())
+	require.Equal(t, "b", labels.Get(1).String())
+
+	user := field(msg, "tag_user").Message()
+	require.Equal(t, "u1", field(user, "id").String())
+	require.Equal(t, int64(42), field(user, "age").Int())
+
+	pieces := field(msg, "tag_pieces").List()
+	require.Equal(t, 2, pieces.Len())
+	require.Equal(t, int64(2), field(pieces.Get(1).Message(), "num").Int())
+	require.True(t, field(pieces.Get(1).Message(), "ok").Bool())
+}
+
+func TestSchemaMismatchedTagTypes(t *testing.T) {
+	schema, _ := mergeTagFields(nil, []*pb.Tag{
+		eventkit.List("mixed", eventkit.Float64("", 0.5), eventkit.Bool("", true)),
+		eventkit.Map("user", eventkit.Int64("id", 1)),
+	}, tagFieldName)
+
+	metadata := &bigquery.TableMetadata{Schema: schema}
+	descriptor, err := toMessageDescriptor(metadata)
+	require.NoError(t, err)
+	s := &Schema{schemeChangeLock: &sync.Mutex{}, tableMetadata: metadata, messageDescriptor: descriptor}
+
+	data, err := s.RecordToPB(&Record{Tags: []*pb.Tag{
+		eventkit.List("mixed", eventkit.Float64("", 0.5), eventkit.Bool("", true), eventkit.Float64("", 2)),
+		eventkit.Map("user", eventkit.String("id", "u1")),
+	}})
+	require.NoError(t, err)
+
+	msg := dynamicpb.NewMessage(descriptor)
+	require.NoError(t, proto.Unmarshal(data, msg))
+	fields := msg.Descriptor().Fields()
+
+	mixed := msg.Get(fields.ByName("tag_mixed")).List()
+	require.Equal(t, 2, mixed.Len())
+	require.Equal(t, 2.0, mixed.Get(1).Float())
+
+	user := msg.Get(fields.ByName("tag_user")).Message()
+	require.False(t, user.Has(user.Descriptor().Fields().ByName("id")))
+}
+
+func TestSchemaHistogram(t *testing.T) {
+	var h eventkit.Histogram
+	h.Observe(0.5)
+	h.Observe(2)
+	tags := []*pb.Tag{h.Tag("latency")}
+
+	schema, changed := mergeTagFields(nil, tags, tagFieldName)
+	require.True(t, changed)
+	require.Len(t, schema, 1)
+	require.Equal(t, bigquery.RecordFieldType, schema[0].Type)
+	buckets := schema[0].Schema[len(schema[0].Schema)-1]
+	require.Equal(t, "buckets", buckets.Name)
+	require.True(t, buckets.Repeated)
+	require.Equal(t, bigquery.RecordFieldType, buckets.Type)
+
+	record := &Record{Tags: tags}
+	fields, _, err := record.Save()
+	require.NoError(t, err)
+	require.NotContains(t, fields, severityField)
+	latency := fields["tag_latency"].(map[string]bigquery.Value)
+	require.Equal(t, int64(2), latency["count"])
+	require.Equal(t, 2.5, latency["sum"])
+	require.Len(t, latency["buckets"], 2)
+}
+
+func TestRecordSeverity(t *testing.T) {
+	record := &Record{Severity: pb.Severity_WARNING}
+	fields, _, err := record.Save()
+	require.NoError(t, err)
+	require.Equal(t, "WARNING", fields[severityField])
+
+	data, err := record.ToJSON()
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"severity":"WARNING"`)
+
+	schema := bigquery.Schema{{Name: "application_name", Type: bigquery.StringFieldType}}
+	_, changed := mergeRecordFields(schema, []*Record{{}})
+	require.False(t, changed, "the severity column is added only when needed")
+
+	merged, changed := mergeRecordFields(schema, []*Record{{}, record, record})
+	require.True(t, changed)
+	require.Len(t, merged, 2)
+	require.Equal(t, severityField, merged[1].Name)
+	require.Len(t, schema, 1)
+
+	_, changed = mergeRecordFields(merged, []*Record{record})
+	require.False(t, changed)
+}
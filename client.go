@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"compress/zlib"
 	"context"
-	"net"
+	"errors"
+	"math/rand/v2"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -17,35 +19,93 @@ import (
 )
 
 const (
-	defaultQueueDepth           = 100
-	defaultMaxUncompressedBytes = 1000
-	defaultCompressionLevel     = zlib.BestCompression
-	defaultFlushInterval        = 15 * time.Second
+	defaultQueueDepth       = 100
+	defaultMaxPacketBytes   = 1000
+	defaultCompressionLevel = zlib.BestCompression
+	defaultFlushInterval    = 15 * time.Second
+
+	// initialCompressionRatio is the estimated compression ratio until the
+	// first packet is compressed.
+	initialCompressionRatio = 0.5
+	// compressionRatioWeight is the weight of the last packet in the
+	// estimated compression ratio.
+	compressionRatioWeight = 0.25
+
+	// packetEventsField is the field number of pb.Packet.Events.
+	packetEventsField = 6
+
+	// ipUDPHeaderSize is the size of the IPv6 and UDP headers, which are
+	// not available for the packet within the MTU.
+	ipUDPHeaderSize = 48
+	// fragmentOverhead is the maximum size of the magic number, the zlib
+	// framing and the fragment fields in a fragment packet.
+	fragmentOverhead = 64
 )
 
-// this is the size of a zlib compressed, serialized pb.Packet with SendOffset
-// set to a reasonable value.
-const trailerSize = 24
+// DiscoverMTU can be used as UDPClient.MTU to use the path MTU known by the
+// kernel. It's supported only on Linux, elsewhere MaxPacketBytes is used.
+const DiscoverMTU = -1
+
+// AutoShards can be used as UDPClient.Shards to use a shard for every
+// processor, as reported by runtime.GOMAXPROCS.
+const AutoShards = -1
+
+// this is the size of a serialized pb.Packet with SendOffset set to a
+// reasonable value.
+const trailerSize = 12
 
 type UDPClient struct {
 	Application string
 	Version     string
 	Instance    string
 	Addr        string
-
-	QueueDepth           int
+	// Collectors are the addresses of the collectors, when there are more
+	// than one. It replaces Addr.
+	Collectors []string
+	// CollectorPolicy decides how the packets are distributed between the
+	// Collectors.
+	CollectorPolicy CollectorPolicy
+
+	// QueueDepth limits the queued events of every priority class.
+	QueueDepth int
+	// PriorityWeights decides how the queues of the priority classes are
+	// drained. By default they are drained by strict priority.
+	PriorityWeights PriorityWeights
+	// MaxPacketBytes limits the size of the compressed datagrams.
+	MaxPacketBytes int
+	// MTU is the maximum transmission unit of the path to the collector.
+	// When it's positive, the datagrams are limited to the MTU minus the IP
+	// and UDP headers instead of MaxPacketBytes. See also DiscoverMTU.
+	MTU int
+	// MaxUncompressedBytes additionally limits the uncompressed size of the
+	// packets, when it's positive.
 	MaxUncompressedBytes int
 	CompressionLevel     int
 	FlushInterval        time.Duration
-
-	initOnce    sync.Once
-	submitQueue chan *Event
-
-	writerPool    *zlib.Writer
-	droppedEvents atomic.Int64
+	// Shards is the number of independent submit queues and packet
+	// builders. Each shard marshals, compresses and flushes its own packets
+	// in a separate goroutine, sharing the sockets, so high event rates are
+	// not limited by a single queue and goroutine. QueueDepth is split
+	// between the shards. The default is a single shard, see also
+	// AutoShards.
+	Shards int
+	// Tags are the tags of the instance, like its region or git commit.
+	// They are sent once in every packet, instead of with every event, and
+	// the collector adds them to the events which don't have them. They
+	// shouldn't be modified after Run is called.
+	Tags []Tag
+
+	initOnce      sync.Once
+	shards        []*shard
+	collectors    []*collector
+	nextCollector atomic.Uint64
+
+	droppedEvents [NumPriorities]atomic.Int64
+	stats         StatsCounter
 }
 
 var _ Destination = &UDPClient{}
+var _ StatsReporter = &UDPClient{}
 
 func NewUDPClient(application, version, instance, addr string) *UDPClient {
 	c := &UDPClient{
@@ -54,129 +114,338 @@ func NewUDPClient(application, version, instance, addr string) *UDPClient {
 		Instance:    instance,
 		Addr:        addr,
 
-		QueueDepth:           defaultQueueDepth,
-		MaxUncompressedBytes: defaultMaxUncompressedBytes,
-		CompressionLevel:     defaultCompressionLevel,
-		FlushInterval:        defaultFlushInterval,
+		QueueDepth:       defaultQueueDepth,
+		MaxPacketBytes:   defaultMaxPacketBytes,
+		CompressionLevel: defaultCompressionLevel,
+		FlushInterval:    defaultFlushInterval,
 	}
 	return c
 }
 
 func (c *UDPClient) init() {
 	c.initOnce.Do(func() {
-		c.submitQueue = make(chan *Event, c.QueueDepth)
+		n := c.Shards
+		if n == AutoShards {
+			n = runtime.GOMAXPROCS(0)
+		}
+		n = max(n, 1)
+		depth := (c.QueueDepth + n - 1) / n
+		for range n {
+			c.shards = append(c.shards, c.newShard(depth))
+		}
+
+		addrs := c.Collectors
+		if len(addrs) == 0 {
+			addrs = []string{c.Addr}
+		}
+		for _, addr := range addrs {
+			c.collectors = append(c.collectors, newCollector(addr))
+		}
 	})
 }
 
-type outgoingPacket struct {
-	buf                      bytes.Buffer
-	zl                       *zlib.Writer
-	written, maxUncompressed int
-	events                   int
-	startTime                time.Time
-
+// shard is a set of submit queues, one for every priority class, with
+// their packet builder.
+type shard struct {
 	client *UDPClient
+	queues [NumPriorities]*submitQueue
+
+	// the state of the packet builder, used only by run.
+	ratio           float64
+	zlibWriter      *zlib.Writer
+	packetBuffer    bytes.Buffer
+	rawBuffer       []byte
+	eventsBuffer    []*Event
+	endsBuffer      []int
+	datagramsBuffer [][]byte
 }
 
-func (c *UDPClient) newOutgoingPacket() *outgoingPacket {
-	op := &outgoingPacket{
-		startTime:       time.Now(),
-		maxUncompressed: c.MaxUncompressedBytes,
-		client:          c,
+func (c *UDPClient) newShard(depth int) *shard {
+	s := &shard{client: c}
+	for class := range s.queues {
+		s.queues[class] = &submitQueue{
+			batches: make(chan []*Event, max(depth, 1)),
+			depth:   depth,
+		}
 	}
-	op.buf.Grow(c.MaxUncompressedBytes)
+	return s
+}
 
-	_, err := op.buf.Write([]byte("EK"))
-	if err != nil {
-		panic(err)
+// submitQueue holds the submitted batches. queued counts their events, and
+// is kept at most depth, so sending to batches never blocks.
+type submitQueue struct {
+	batches chan []*Event
+	queued  atomic.Int64
+	depth   int
+}
+
+// queued returns the number of events in the queues of the shard.
+func (s *shard) queued() (queued int64) {
+	for _, q := range s.queues {
+		queued += q.queued.Load()
 	}
+	return queued
+}
 
-	// grab a zlib writer from pool, when one exists.
-	op.zl, c.writerPool = c.writerPool, nil
-	if op.zl == nil {
-		op.zl, err = zlib.NewWriterLevel(&op.buf, c.CompressionLevel)
-		if err != nil {
-			panic(err)
-		}
-	} else {
-		op.zl.Reset(&op.buf)
+// outgoingPacket collects the events of a datagram. The events are encoded
+// uncompressed, and compressed only once by finalize: the compressed size is
+// estimated with the compression ratio of the previous packets.
+type outgoingPacket struct {
+	enc       *picobuf.Encoder
+	ends      []int // end offsets of the events in the encoded buffer
+	events    []*Event
+	startTime time.Time
+
+	// scratch is reused to encode the events, encodeScratch is its bound
+	// Encode method, to avoid allocating a closure for every event.
+	scratch       pb.Event
+	encodeScratch func(*picobuf.Encoder) bool
+
+	shard *shard
+}
+
+func (s *shard) newOutgoingPacket() *outgoingPacket {
+	op := &outgoingPacket{
+		enc:       picobuf.NewEncoderBuffer(s.rawBuffer),
+		events:    s.eventsBuffer,
+		ends:      s.endsBuffer,
+		startTime: time.Now(),
+		shard:     s,
 	}
+	s.rawBuffer, s.eventsBuffer, s.endsBuffer = nil, nil, nil
+	op.encodeScratch = op.scratch.Encode
 
-	data, err := picobuf.Marshal(&pb.Packet{
+	s.client.packetHeader(op.startTime).Encode(op.enc)
+	return op
+}
+
+// packetHeader returns the fields of the packets, which are sent before the
+// events.
+func (c *UDPClient) packetHeader(start time.Time) *pb.Packet {
+	return &pb.Packet{
 		Application:        c.Application,
 		ApplicationVersion: c.Version,
 		Instance:           c.Instance,
-		StartTimestamp:     pb.AsTimestamp(op.startTime),
-	})
-	if err != nil {
-		panic(err)
+		StartTimestamp:     pb.AsTimestamp(start),
+		Tags:               c.Tags,
+	}
+}
+
+// addEvent adds the event to the packet, and returns true when the packet
+// is estimated to be full.
+func (op *outgoingPacket) addEvent(ev *Event) (full bool) {
+	op.scratch = pb.Event{
+		Name:              ev.Name,
+		Scope:             ev.Scope,
+		TimestampOffsetNs: int64(ev.Timestamp.Sub(op.startTime)),
+		Tags:              ev.Tags,
+		SessionId:         ev.ID.Session,
+		Sequence:          ev.ID.Sequence,
+		Severity:          ev.Severity,
 	}
+	op.enc.AlwaysMessage(packetEventsField, op.encodeScratch)
+	op.scratch = pb.Event{}
 
-	op.written += 2 + len(data)
+	op.ends = append(op.ends, len(op.enc.Buffer()))
+	op.events = append(op.events, ev)
+	return op.full()
+}
 
-	_, err = op.zl.Write(data)
-	if err != nil {
-		panic(err)
+func (op *outgoingPacket) full() bool {
+	c := op.shard.client
+	uncompressed := len(op.enc.Buffer()) + trailerSize
+	if c.MaxUncompressedBytes > 0 && uncompressed > c.MaxUncompressedBytes {
+		return true
 	}
+	return 2+int(float64(uncompressed)*op.shard.compressionRatio()) > c.maxPacketBytes()
+}
 
-	return op
+// maxPacketBytes returns the size limit of the datagrams.
+func (c *UDPClient) maxPacketBytes() int {
+	mtu := c.MTU
+	if mtu == DiscoverMTU {
+		// the smallest MTU of the collectors.
+		mtu = 0
+		for _, co := range c.collectors {
+			if known := int(co.mtu.Load()); known > 0 && (mtu == 0 || known < mtu) {
+				mtu = known
+			}
+		}
+	}
+	if mtu > 0 {
+		return mtu - ipUDPHeaderSize
+	}
+	return c.MaxPacketBytes
 }
 
-func (op *outgoingPacket) finalize() []byte {
-	data, err := picobuf.Marshal(&pb.Packet{
+// finalize compresses the packet into datagrams. When the compressed packet
+// is larger than the limit, the last events are left out and returned, so
+// they can be sent with the next packet. A single event which is too large is
+// split into fragments, which are reassembled by the collector.
+//
+// The returned data is valid until the next packet is finalized. When the
+// event is too large even for fragmentation, no datagrams are returned.
+func (op *outgoingPacket) finalize() (datagrams [][]byte, leftover []*Event) {
+	s := op.shard
+	limit := s.client.maxPacketBytes()
+
+	var trailer [16]byte
+	trailerData, err := picobuf.MarshalBuffer(&pb.Packet{
 		SendOffsetNs: int64(time.Since(op.startTime)),
-	})
+	}, trailer[:0])
 	if err != nil {
 		panic(err)
 	}
 
-	_, err = op.zl.Write(data)
-	if err != nil {
-		panic(err)
+	n := len(op.events)
+	var data []byte
+	for {
+		raw := op.enc.Buffer()
+		if n < len(op.ends) {
+			raw = raw[:op.ends[n-1]]
+		}
+		data = s.compress(raw, trailerData)
+		if len(data) <= limit || n <= 1 {
+			break
+		}
+		// back off in proportion to the overshoot.
+		n = max(1, min(n-1, n*limit/len(data)))
+	}
+	if n > 0 {
+		s.observeCompressionRatio(op.ends[n-1]+len(trailerData), len(data)-2)
 	}
 
-	err = op.zl.Close()
-	if err != nil {
-		panic(err)
+	if len(data) > limit && n == 1 {
+		datagrams = op.fragment(op.events[0], trailerData, limit)
+	} else {
+		datagrams = append(s.datagramsBuffer[:0], data)
+		s.datagramsBuffer = datagrams
 	}
 
-	// put zlib writer back to the pool.
-	op.client.writerPool, op.zl = op.zl, nil
+	leftover = append(leftover, op.events[n:]...)
+	clear(op.events)
+	op.events = op.events[:n]
 
-	return op.buf.Bytes()
+	// give the buffers back to the shard for the next packet.
+	s.rawBuffer, s.eventsBuffer, s.endsBuffer = op.enc.Buffer()[:0], op.events[:0], op.ends[:0]
+	return datagrams, leftover
 }
 
-func (op *outgoingPacket) addEvent(ev *Event) (full bool) {
-	var v pb.Event
-
-	v.Name = ev.Name
-	v.Scope = ev.Scope
-	v.TimestampOffsetNs = int64(ev.Timestamp.Sub(op.startTime))
-	v.Tags = ev.Tags
-
-	data, err := picobuf.Marshal(&pb.Packet{Events: []*pb.Event{&v}})
+// fragment splits the encoded event into fragment packets, each fitting in
+// limit bytes.
+func (op *outgoingPacket) fragment(ev *Event, trailer []byte, limit int) (datagrams [][]byte) {
+	c := op.shard.client
+	eventData, err := picobuf.Marshal(&pb.Event{
+		Name:              ev.Name,
+		Scope:             ev.Scope,
+		TimestampOffsetNs: int64(ev.Timestamp.Sub(op.startTime)),
+		Tags:              ev.Tags,
+		SessionId:         ev.ID.Session,
+		Sequence:          ev.ID.Sequence,
+		Severity:          ev.Severity,
+	})
 	if err != nil {
 		panic(err)
 	}
-
-	op.written += len(data)
-
-	_, err = op.zl.Write(data)
+	header, err := picobuf.Marshal(c.packetHeader(op.startTime))
 	if err != nil {
 		panic(err)
 	}
 
-	err = op.zl.Flush()
-	if err != nil {
+	chunk := limit - len(header) - len(trailer) - fragmentOverhead
+	if chunk <= 0 {
+		return nil
+	}
+	count := (len(eventData) + chunk - 1) / chunk
+	if count > pb.MaxFragments {
+		return nil
+	}
+
+	id := rand.Uint64()
+	for index := range count {
+		fragment, err := picobuf.Marshal(&pb.Packet{
+			Fragment: &pb.Fragment{
+				Id:    id,
+				Index: uint32(index),
+				Count: uint32(count),
+				Data:  eventData[index*chunk : min(len(eventData), (index+1)*chunk)],
+			},
+		})
+		if err != nil {
+			panic(err)
+		}
+		datagrams = append(datagrams, bytes.Clone(op.shard.compress(header, fragment, trailer)))
+	}
+	return datagrams
+}
+
+// compress returns the datagram with the compressed parts. It's valid until
+// the next call.
+func (s *shard) compress(parts ...[]byte) []byte {
+	s.packetBuffer.Reset()
+	s.packetBuffer.WriteString("EK")
+
+	var err error
+	if s.zlibWriter == nil {
+		s.zlibWriter, err = zlib.NewWriterLevel(&s.packetBuffer, s.client.CompressionLevel)
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		s.zlibWriter.Reset(&s.packetBuffer)
+	}
+	for _, part := range parts {
+		if _, err = s.zlibWriter.Write(part); err != nil {
+			panic(err)
+		}
+	}
+	if err = s.zlibWriter.Close(); err != nil {
 		panic(err)
 	}
+	return s.packetBuffer.Bytes()
+}
+
+// compressionRatio returns the estimated compressed/uncompressed size ratio
+// of the packets.
+func (s *shard) compressionRatio() float64 {
+	if s.ratio == 0 {
+		return initialCompressionRatio
+	}
+	return s.ratio
+}
 
-	op.events += 1
-	return (op.written + trailerSize) > op.maxUncompressed
+// observeCompressionRatio updates the estimated compression ratio with the
+// sizes of a compressed packet.
+func (s *shard) observeCompressionRatio(uncompressed, compressed int) {
+	observed := float64(compressed) / float64(uncompressed)
+	if s.ratio == 0 {
+		s.ratio = observed
+		return
+	}
+	s.ratio += (observed - s.ratio) * compressionRatioWeight
 }
 
 func (c *UDPClient) Run(ctx context.Context) {
 	c.init()
+	defer func() {
+		for _, co := range c.collectors {
+			co.close()
+		}
+	}()
+
+	var shards errgroup.Group
+	for _, s := range c.shards {
+		shards.Go(func() error {
+			s.run(ctx)
+			return nil
+		})
+	}
+	_ = shards.Wait()
+}
+
+// run builds and sends the packets of the shard until ctx is canceled.
+func (s *shard) run(ctx context.Context) {
+	c := s.client
 
 	ticker := utils.NewJitteredTicker(c.FlushInterval)
 	var background errgroup.Group
@@ -186,79 +455,215 @@ func (c *UDPClient) Run(ctx context.Context) {
 		return nil
 	})
 
-	p := c.newOutgoingPacket()
+	p := s.newOutgoingPacket()
 
+	var add func(ev *Event)
 	sendAndReset := func() {
-		_ = c.send(p, c.Addr)
-		p = c.newOutgoingPacket()
+		datagrams, leftover := p.finalize()
+		_ = s.send(datagrams, len(p.events))
+		p = s.newOutgoingPacket()
+		for _, ev := range leftover {
+			add(ev)
+		}
+	}
+	add = func(ev *Event) {
+		if p.addEvent(ev) {
+			sendAndReset()
+		}
+	}
+	addEvents := func(class int, events []*Event) {
+		s.queues[class].queued.Add(-int64(len(events)))
+		for _, ev := range events {
+			add(ev)
+		}
+	}
+
+	drainer := NewPriorityDrainer(c.PriorityWeights)
+	waiting := func(class int) bool { return len(s.queues[class].batches) > 0 }
+	shutdown := func() {
+		var left int
+		for _, q := range s.queues {
+			left += len(q.batches)
+		}
+		for range left {
+			class, ok := drainer.Next(waiting)
+			if !ok {
+				break
+			}
+			addEvents(class, <-s.queues[class].batches)
+		}
+		for len(p.events) > 0 {
+			sendAndReset()
+		}
 	}
 
 	for {
-		if drops := c.droppedEvents.Load(); drops > 0 {
-			c.droppedEvents.Add(-drops)
-			if p.addEvent(&Event{
-				Name:      "drops",
-				Scope:     []string{"storj.io/eventkit"},
-				Timestamp: time.Now(),
-				Tags:      []Tag{Int64("events", drops)},
-			}) {
-				sendAndReset()
+		if drops := c.takeDrops(); drops != nil {
+			add(drops)
+		}
+
+		// only this goroutine receives from the queues, so a waiting
+		// batch can't be taken by anybody else.
+		if class, ok := drainer.Next(waiting); ok {
+			addEvents(class, <-s.queues[class].batches)
+			select {
+			case <-ticker.C:
+				if len(p.events) > 0 {
+					sendAndReset()
+				}
+			case <-ctx.Done():
+				shutdown()
+				return
+			default:
 			}
+			continue
 		}
 
+		// the select waits on every priority class, this fails to
+		// compile when their number changes.
+		_ = [1]struct{}{}[NumPriorities-3]
 		select {
-		case em := <-c.submitQueue:
-			if p.addEvent(em) {
-				sendAndReset()
-			}
+		case events := <-s.queues[0].batches:
+			addEvents(0, events)
+		case events := <-s.queues[1].batches:
+			addEvents(1, events)
+		case events := <-s.queues[2].batches:
+			addEvents(2, events)
 		case <-ticker.C:
-			if p.events > 0 {
+			if len(p.events) > 0 {
 				sendAndReset()
 			}
 		case <-ctx.Done():
-			left := len(c.submitQueue)
-			for range left {
-				if p.addEvent(<-c.submitQueue) {
-					sendAndReset()
-				}
-			}
-			if p.events > 0 {
-				_ = c.send(p, c.Addr)
-			}
+			shutdown()
 			return
 		}
 	}
 }
 
-func (c *UDPClient) send(packet *outgoingPacket, addr string) (err error) {
-	laddr, err := net.ResolveUDPAddr("udp", addr)
-	if err != nil {
-		return err
+// takeDrops returns the event reporting the dropped events since the last
+// call, or nil when there weren't any.
+func (c *UDPClient) takeDrops() *Event {
+	var total int64
+	var tags []Tag
+	for class, p := range Priorities {
+		if drops := c.droppedEvents[class].Swap(0); drops > 0 {
+			total += drops
+			tags = append(tags, Int64(p.String(), drops))
+		}
 	}
-
-	conn, err := net.DialUDP("udp", nil, laddr)
-	if err != nil {
-		return err
+	if total == 0 {
+		return nil
 	}
+	return &Event{
+		Name:      "drops",
+		Scope:     []string{"storj.io/eventkit"},
+		Timestamp: time.Now(),
+		Tags:      append([]Tag{Int64("events", total)}, tags...),
+		Priority:  PriorityCritical,
+		Severity:  SeverityWarning,
+	}
+}
+
+// errEventTooLarge is reported for events which can't be sent even in
+// fragments.
+var errEventTooLarge = errors.New("eventkit: event is too large")
+
+func (s *shard) send(datagrams [][]byte, events int) (err error) {
+	c := s.client
 	defer func() {
-		if errClose := conn.Close(); err == nil && errClose != nil {
-			err = errClose
+		if err != nil {
+			c.stats.Failed(events, err)
+		} else {
+			c.stats.Sent(events)
 		}
 	}()
+	if len(datagrams) == 0 {
+		return errEventTooLarge
+	}
 
-	_, _, err = conn.WriteMsgUDP(packet.finalize(), nil, nil)
-	return err
+	return c.deliver(datagrams, events)
 }
 
+// Submit implements Destination.
+//
+// The events are queued by their priority. The events of the same priority
+// are queued together: either all of them are accepted, or all of them are
+// dropped when they don't fit in the queue (QueueDepth events, split between
+// the shards). A batch larger than the queue is accepted only when the queue
+// is empty. The events slice must not be modified after Submit.
 func (c *UDPClient) Submit(events ...*Event) {
+	if len(events) == 0 {
+		return
+	}
 	c.init()
 
-	for _, event := range events {
-		select {
-		case c.submitQueue <- event:
+	priority := events[0].Priority
+	for _, ev := range events[1:] {
+		if ev.Priority != priority {
+			c.submitMixed(events)
 			return
-		default:
-			c.droppedEvents.Add(1)
 		}
 	}
+	c.submit(priority.Class(), events)
+}
+
+// submitMixed splits the events by their priority class.
+func (c *UDPClient) submitMixed(events []*Event) {
+	var byClass [NumPriorities][]*Event
+	for _, ev := range events {
+		class := ev.Priority.Class()
+		byClass[class] = append(byClass[class], ev)
+	}
+	for class, events := range byClass {
+		if len(events) > 0 {
+			c.submit(class, events)
+		}
+	}
+}
+
+func (c *UDPClient) submit(class int, events []*Event) {
+	q := c.pickShard(class)
+	if !q.reserve(len(events)) {
+		c.droppedEvents[class].Add(int64(len(events)))
+		c.stats.DroppedPriority(Priorities[class], len(events))
+		return
+	}
+	q.batches <- events
+}
+
+// pickShard returns the queue of the class in the less loaded one of two
+// random shards.
+func (c *UDPClient) pickShard(class int) *submitQueue {
+	if len(c.shards) == 1 {
+		return c.shards[0].queues[class]
+	}
+	a := c.shards[rand.IntN(len(c.shards))].queues[class]
+	b := c.shards[rand.IntN(len(c.shards))].queues[class]
+	if b.queued.Load() < a.queued.Load() {
+		return b
+	}
+	return a
+}
+
+// reserve reserves room for n events in the queue.
+func (q *submitQueue) reserve(n int) bool {
+	for {
+		queued := q.queued.Load()
+		if queued > 0 && queued+int64(n) > int64(q.depth) {
+			return false
+		}
+		if q.queued.CompareAndSwap(queued, queued+int64(n)) {
+			return true
+		}
+	}
+}
+
+// Stats implements StatsReporter.
+func (c *UDPClient) Stats() Stats {
+	c.init()
+	var queued int64
+	for _, s := range c.shards {
+		queued += s.queued()
+	}
+	return c.stats.Snapshot(int(queued))
 }
@@ -1,7 +1,10 @@
 package eventkit
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,11 +22,13 @@ func BenchmarkOutgoingPacket(b *testing.B) {
 		Tags:  []*pb.Tag{String("key", "value")},
 	}
 
+	shard := client.newShard(client.QueueDepth)
+
 	b.ReportAllocs()
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		packet := client.newOutgoingPacket()
+		packet := shard.newOutgoingPacket()
 		for range 70 {
 			event.Timestamp = event.Timestamp.Add(100 * time.Millisecond)
 			full := packet.addEvent(&Event{})
@@ -31,8 +36,272 @@ func BenchmarkOutgoingPacket(b *testing.B) {
 				b.Fatal("filled internal buffer")
 			}
 		}
-		_ = packet.finalize()
+		_, _ = packet.finalize()
+	}
+}
+
+func BenchmarkUDPClientSubmit(b *testing.B) {
+	l, err := transport.ListenUDP("127.0.0.1:0")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer func() { _ = l.Close() }()
+
+	for _, shards := range []int{1, AutoShards} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			client := NewUDPClient("application", "v1.0.0", "instance", l.LocalAddr().String())
+			client.Shards = shards
+			client.QueueDepth = 10000
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				client.Run(ctx)
+			}()
+			defer func() {
+				cancel()
+				<-done
+			}()
+
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					client.Submit(&Event{Name: "event", Scope: []string{"alpha", "beta"}, Timestamp: time.Now()})
+				}
+			})
+		})
+	}
+}
+
+func TestOutgoingPacketSize(t *testing.T) {
+	client := NewUDPClient("application", "v1.0.0", "instance", "127.0.0.1:0")
+
+	shard := client.newShard(client.QueueDepth)
+
+	var uncompressed int
+	var leftover []*Event
+	for i := 0; i < 5; i++ {
+		packet := shard.newOutgoingPacket()
+		events := 0
+		for _, ev := range leftover {
+			packet.addEvent(ev)
+			events++
+		}
+		for j := 0; !packet.full(); j++ {
+			packet.addEvent(&Event{
+				Name:      "upload",
+				Scope:     []string{"storj.io/storj/satellite/metainfo"},
+				Timestamp: time.Now(),
+				Tags: []*pb.Tag{
+					Int64("segment", int64(i*1000+j)),
+					String("bucket", fmt.Sprintf("bucket-%d", j%7)),
+					Duration("duration", time.Duration(j)*time.Millisecond),
+				},
+			})
+			events++
+		}
+		uncompressed = len(packet.enc.Buffer())
+
+		var datagrams [][]byte
+		datagrams, leftover = packet.finalize()
+		requireEqual(t, len(datagrams), 1)
+		data := datagrams[0]
+		if len(data) > client.MaxPacketBytes {
+			t.Fatalf("packet is %d bytes", len(data))
+		}
+
+		parsed, err := transport.ParsePacket(data)
+		requireNoError(t, err)
+		requireEqual(t, len(parsed.Events), events-len(leftover))
+		requireEqual(t, parsed.Application, "application")
+	}
+
+	// the packets are filled based on the compressed size.
+	if uncompressed <= client.MaxPacketBytes {
+		t.Fatalf("uncompressed packet is only %d bytes", uncompressed)
+	}
+}
+
+func TestOutgoingPacketFragments(t *testing.T) {
+	client := NewUDPClient("application", "v1.0.0", "instance", "127.0.0.1:0")
+	client.MTU = 576
+
+	large := make([]byte, 8000)
+	for i := range large {
+		large[i] = byte(i * 7919 >> 3)
+	}
+	packet := client.newShard(client.QueueDepth).newOutgoingPacket()
+	packet.addEvent(&Event{
+		Name:      "crash",
+		Scope:     []string{"storj.io/storj"},
+		Timestamp: time.Now(),
+		Tags:      []*pb.Tag{Bytes("stack", large)},
+	})
+	datagrams, leftover := packet.finalize()
+	requireEqual(t, len(leftover), 0)
+	if len(datagrams) < 2 {
+		t.Fatalf("expected fragments, got %d datagrams", len(datagrams))
+	}
+
+	reassembler := transport.NewReassembler(time.Minute)
+	// the fragments are reassembled in any order.
+	for i := len(datagrams) - 1; i >= 0; i-- {
+		if len(datagrams[i]) > 576-ipUDPHeaderSize {
+			t.Fatalf("fragment is %d bytes", len(datagrams[i]))
+		}
+		parsed, err := transport.ParsePacket(datagrams[i])
+		requireNoError(t, err)
+		requireEqual(t, len(parsed.Events), 0)
+
+		complete, err := reassembler.Add("127.0.0.1:1234", parsed, time.Now())
+		requireNoError(t, err)
+		if i > 0 {
+			requireEqual(t, complete, (*pb.Packet)(nil))
+			continue
+		}
+		requireEqual(t, complete.Application, "application")
+		requireEqual(t, len(complete.Events), 1)
+		requireEqual(t, complete.Events[0].Name, "crash")
+		requireEqual(t, complete.Events[0].Tags[0].Value.(*pb.Tag_Bytes).Bytes, large)
 	}
+
+	// incomplete events expire.
+	parsed, err := transport.ParsePacket(datagrams[0])
+	requireNoError(t, err)
+	_, err = reassembler.Add("127.0.0.1:1234", parsed, time.Now())
+	requireNoError(t, err)
+	requireEqual(t, reassembler.Expire(time.Now().Add(2*time.Minute)), 1)
+}
+
+func TestUDPClientSubmitBatch(t *testing.T) {
+	l, err := transport.ListenUDP("127.0.0.1:0")
+	requireNoError(t, err)
+	defer func() { _ = l.Close() }()
+
+	client := NewUDPClient("application", "v1.0.0", "instance", l.LocalAddr().String())
+	client.QueueDepth = 3
+
+	client.Submit(&Event{Name: "a"}, &Event{Name: "b"})
+	// doesn't fit in the queue, so neither of them is accepted.
+	client.Submit(&Event{Name: "c"}, &Event{Name: "d"})
+	client.Submit(&Event{Name: "e"})
+
+	stats := client.Stats()
+	requireEqual(t, stats.Queued, int64(3))
+	requireEqual(t, stats.Dropped, int64(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.Run(ctx)
+
+	payload, _, err := l.Next()
+	requireNoError(t, err)
+	packet, err := transport.ParsePacket(payload)
+	requireNoError(t, err)
+
+	var names []string
+	for _, event := range packet.Events {
+		names = append(names, event.Name)
+	}
+	requireEqual(t, names, []string{"drops", "a", "b", "e"})
+	requireEqual(t, packet.Events[0].Tags[0].ValueString(), "2")
+
+	stats = client.Stats()
+	requireEqual(t, stats.Queued, int64(0))
+	requireEqual(t, stats.Sent, int64(4))
+
+	// a batch larger than the queue is accepted when the queue is empty.
+	client.Submit(&Event{Name: "a"}, &Event{Name: "b"}, &Event{Name: "c"}, &Event{Name: "d"})
+	requireEqual(t, client.Stats().Queued, int64(4))
+	client.Submit(&Event{Name: "e"})
+	requireEqual(t, client.Stats().Dropped, int64(3))
+}
+
+func TestUDPClientPriorities(t *testing.T) {
+	l, err := transport.ListenUDP("127.0.0.1:0")
+	requireNoError(t, err)
+	defer func() { _ = l.Close() }()
+
+	client := NewUDPClient("application", "v1.0.0", "instance", l.LocalAddr().String())
+	client.QueueDepth = 2
+
+	for _, name := range []string{"d1", "d2", "d3"} {
+		client.Submit(&Event{Name: name, Priority: PriorityDebug})
+	}
+	// the debug events don't take the room of the others.
+	client.Submit(&Event{Name: "n"}, &Event{Name: "c", Priority: PriorityCritical})
+
+	stats := client.Stats()
+	requireEqual(t, stats.Queued, int64(4))
+	requireEqual(t, stats.Dropped, int64(1))
+	requireEqual(t, stats.DroppedByPriority, map[string]int64{"debug": 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.Run(ctx)
+
+	payload, _, err := l.Next()
+	requireNoError(t, err)
+	packet, err := transport.ParsePacket(payload)
+	requireNoError(t, err)
+
+	var names []string
+	for _, event := range packet.Events {
+		names = append(names, event.Name)
+	}
+	requireEqual(t, names, []string{"drops", "c", "n", "d1", "d2"})
+	requireEqual(t, packet.Events[0].Tags[1].Key, "debug")
+}
+
+func TestUDPClientShards(t *testing.T) {
+	l, err := transport.ListenUDP("127.0.0.1:0")
+	requireNoError(t, err)
+	defer func() { _ = l.Close() }()
+
+	client := NewUDPClient("application", "v1.0.0", "instance", l.LocalAddr().String())
+	client.Shards = 4
+	client.QueueDepth = 1000
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 50 {
+				client.Submit(&Event{Name: "event", Tags: []*pb.Tag{Int64("id", int64(i*50+j))}})
+			}
+		}()
+	}
+	wg.Wait()
+	requireEqual(t, len(client.shards), 4)
+	requireEqual(t, client.Stats().Queued, int64(400))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.Run(ctx)
+
+	seen := map[int64]bool{}
+	var source string
+	for len(seen) < 400 {
+		payload, addr, err := l.Next()
+		requireNoError(t, err)
+		// the shards share a socket.
+		if source == "" {
+			source = addr.String()
+		}
+		requireEqual(t, addr.String(), source)
+
+		packet, err := transport.ParsePacket(payload)
+		requireNoError(t, err)
+		for _, event := range packet.Events {
+			seen[event.Tags[0].Value.(*pb.Tag_Int64).Int64] = true
+		}
+	}
+
+	stats := client.Stats()
+	requireEqual(t, stats.Queued, int64(0))
+	requireEqual(t, stats.Sent, int64(400))
 }
 
 func TestZeroValueType(t *testing.T) {
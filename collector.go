@@ -0,0 +1,277 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// collectorRetryInterval is how long a collector is skipped after a
+	// send error, when there are other collectors to send to.
+	collectorRetryInterval = 10 * time.Second
+	// collectorRedialInterval is the age of the sockets, after which they
+	// are dialed again, so the changes of the collector address are
+	// followed.
+	collectorRedialInterval = time.Minute
+)
+
+// CollectorPolicy decides how the packets are distributed when a UDPClient
+// has more than one collector.
+type CollectorPolicy int
+
+const (
+	// CollectorsAll sends every packet to all collectors, for redundancy.
+	CollectorsAll CollectorPolicy = iota
+	// CollectorsRoundRobin sends every packet to the next available
+	// collector.
+	CollectorsRoundRobin
+	// CollectorsFailover sends the packets to the first available
+	// collector.
+	CollectorsFailover
+)
+
+// String implements fmt.Stringer.
+func (p CollectorPolicy) String() string {
+	switch p {
+	case CollectorsAll:
+		return "all"
+	case CollectorsRoundRobin:
+		return "round-robin"
+	case CollectorsFailover:
+		return "failover"
+	}
+	return fmt.Sprintf("CollectorPolicy(%d)", int(p))
+}
+
+// ParseCollectorPolicy parses the name of a policy, as returned by String.
+func ParseCollectorPolicy(name string) (CollectorPolicy, error) {
+	for _, p := range []CollectorPolicy{CollectorsAll, CollectorsRoundRobin, CollectorsFailover} {
+		if strings.EqualFold(name, p.String()) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown collector policy %q, please use all/round-robin/failover", name)
+}
+
+// CollectorStats are the Stats of one collector of a UDPClient.
+type CollectorStats struct {
+	Addr string `json:"addr"`
+	// Available is false while the collector is skipped after a send error.
+	Available bool `json:"available"`
+	Stats
+}
+
+// collector is a destination address of UDPClient. A collector becomes
+// unavailable for a while after a send error, which includes the ICMP
+// unreachable errors reported by the kernel for the earlier packets.
+type collector struct {
+	addr string
+
+	mu     sync.Mutex
+	conn   *collectorConn
+	dialed time.Time
+
+	unavailableUntil atomic.Int64 // unix nanoseconds
+	mtu              atomic.Int64
+	stats            StatsCounter
+}
+
+// collectorConn is a socket of a collector. When it's replaced, it's closed
+// only after the writers using it are done, as the shards write
+// concurrently.
+type collectorConn struct {
+	*net.UDPConn
+
+	// writers and retired are protected by collector.mu.
+	writers int
+	retired bool
+}
+
+func newCollector(addr string) *collector {
+	return &collector{addr: addr}
+}
+
+// available returns whether the collector can be used at now.
+func (co *collector) available(now time.Time) bool {
+	return now.UnixNano() >= co.unavailableUntil.Load()
+}
+
+// connect returns the socket of the collector. It's dialed on the first
+// use, after errors, and when it's older than collectorRedialInterval. The
+// socket must be released after the write.
+func (co *collector) connect() (*collectorConn, error) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	if co.conn != nil && time.Since(co.dialed) > collectorRedialInterval {
+		co.retire()
+	}
+	if co.conn == nil {
+		raddr, err := net.ResolveUDPAddr("udp", co.addr)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := net.DialUDP("udp", nil, raddr)
+		if err != nil {
+			return nil, err
+		}
+		co.conn, co.dialed = &collectorConn{UDPConn: conn}, time.Now()
+	}
+	co.conn.writers++
+	return co.conn, nil
+}
+
+// release ends a write to the socket returned by connect.
+func (co *collector) release(conn *collectorConn) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	conn.writers--
+	if conn.retired && conn.writers == 0 {
+		_ = conn.Close()
+	}
+}
+
+// retire removes the current socket, which is closed now or by the release
+// of its last writer. co.mu must be held.
+func (co *collector) retire() {
+	co.conn.retired = true
+	if co.conn.writers == 0 {
+		_ = co.conn.Close()
+	}
+	co.conn = nil
+}
+
+// reset closes the socket after an error, unless it has already been
+// replaced.
+func (co *collector) reset(conn *collectorConn) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	if co.conn == conn {
+		co.retire()
+	}
+}
+
+func (co *collector) close() {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	if co.conn != nil {
+		co.retire()
+	}
+}
+
+// write sends the datagrams of a packet with the given number of events to
+// the collector, updating its stats.
+func (co *collector) write(datagrams [][]byte, events int, discoverMTU bool) (sent int, err error) {
+	defer func() {
+		if err != nil {
+			co.stats.Failed(events, err)
+			co.unavailableUntil.Store(time.Now().Add(collectorRetryInterval).UnixNano())
+		} else {
+			co.stats.Sent(events)
+			co.unavailableUntil.Store(0)
+		}
+	}()
+
+	conn, err := co.connect()
+	if err != nil {
+		return 0, err
+	}
+	defer co.release(conn)
+	for _, datagram := range datagrams {
+		n, _, err := conn.WriteMsgUDP(datagram, nil, nil)
+		sent += n
+		co.stats.BytesSent(n)
+		if err != nil {
+			co.reset(conn)
+			return sent, err
+		}
+	}
+
+	if discoverMTU {
+		if mtu, ok := pathMTU(conn.UDPConn); ok {
+			co.mtu.Store(int64(mtu))
+		}
+	}
+	return sent, nil
+}
+
+// deliver sends the datagrams of a packet with the given number of events
+// to the collectors, according to the CollectorPolicy.
+func (c *UDPClient) deliver(datagrams [][]byte, events int) error {
+	discoverMTU := c.MTU == DiscoverMTU
+	var errs []error
+	writeTo := func(co *collector) bool {
+		n, err := co.write(datagrams, events, discoverMTU)
+		c.stats.BytesSent(n)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", co.addr, err))
+			return false
+		}
+		return true
+	}
+
+	if len(c.collectors) == 1 || c.CollectorPolicy == CollectorsAll {
+		delivered := false
+		for _, co := range c.collectors {
+			if writeTo(co) {
+				delivered = true
+			}
+		}
+		if !delivered {
+			return errors.Join(errs...)
+		}
+		// the events are delivered, but the failure is still worth
+		// reporting.
+		c.stats.Error(errors.Join(errs...))
+		return nil
+	}
+
+	var start int
+	if c.CollectorPolicy == CollectorsRoundRobin {
+		start = int((c.nextCollector.Add(1) - 1) % uint64(len(c.collectors)))
+	}
+	// the unavailable collectors are tried only when the available ones
+	// fail.
+	now := time.Now()
+	var unavailable []*collector
+	for i := range c.collectors {
+		co := c.collectors[(start+i)%len(c.collectors)]
+		if !co.available(now) {
+			unavailable = append(unavailable, co)
+			continue
+		}
+		if writeTo(co) {
+			return nil
+		}
+	}
+	for _, co := range unavailable {
+		if writeTo(co) {
+			return nil
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CollectorStats returns the Stats of the collectors, in the order of
+// their configuration. The events sent to more than one collector are
+// counted by each of them.
+func (c *UDPClient) CollectorStats() []CollectorStats {
+	c.init()
+	now := time.Now()
+	stats := make([]CollectorStats, 0, len(c.collectors))
+	for _, co := range c.collectors {
+		stats = append(stats, CollectorStats{
+			Addr:      co.addr,
+			Available: co.available(now),
+			Stats:     co.stats.Snapshot(0),
+		})
+	}
+	return stats
+}
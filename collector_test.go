@@ -0,0 +1,117 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func listenCollector(t *testing.T) *net.UDPConn {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	requireNoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// received returns the number of datagrams waiting on the socket.
+func received(t *testing.T, conn *net.UDPConn) (n int) {
+	buf := make([]byte, 1024)
+	for {
+		requireNoError(t, conn.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+		if _, err := conn.Read(buf); err != nil {
+			return n
+		}
+		n++
+	}
+}
+
+func TestUDPClientCollectors(t *testing.T) {
+	a, b := listenCollector(t), listenCollector(t)
+	datagrams := [][]byte{[]byte("EK")}
+
+	for _, tc := range []struct {
+		policy CollectorPolicy
+		a, b   int
+	}{
+		{policy: CollectorsAll, a: 4, b: 4},
+		{policy: CollectorsRoundRobin, a: 2, b: 2},
+		{policy: CollectorsFailover, a: 4, b: 0},
+	} {
+		client := NewUDPClient("application", "v1.0.0", "instance", "")
+		client.Collectors = []string{a.LocalAddr().String(), b.LocalAddr().String()}
+		client.CollectorPolicy = tc.policy
+		client.init()
+		for range 4 {
+			requireNoError(t, client.deliver(datagrams, 1))
+		}
+		requireEqual(t, received(t, a), tc.a)
+		requireEqual(t, received(t, b), tc.b)
+
+		stats := client.CollectorStats()
+		requireEqual(t, stats[0].Sent, int64(tc.a))
+		requireEqual(t, stats[1].Sent, int64(tc.b))
+	}
+}
+
+func TestUDPClientFailover(t *testing.T) {
+	primary, secondary := listenCollector(t), listenCollector(t)
+	datagrams := [][]byte{[]byte("EK")}
+
+	client := NewUDPClient("application", "v1.0.0", "instance", "")
+	client.Collectors = []string{primary.LocalAddr().String(), secondary.LocalAddr().String()}
+	client.CollectorPolicy = CollectorsFailover
+	client.init()
+
+	requireNoError(t, client.deliver(datagrams, 1))
+	requireEqual(t, received(t, primary), 1)
+	_ = primary.Close()
+
+	// the ICMP unreachable error of the closed port is reported by one of
+	// the following sends, which then fails over to the secondary.
+	for i := 0; received(t, secondary) == 0; i++ {
+		if i == 100 {
+			t.Fatal("didn't fail over")
+		}
+		requireNoError(t, client.deliver(datagrams, 1))
+	}
+
+	stats := client.CollectorStats()
+	requireEqual(t, stats[0].Available, false)
+	requireEqual(t, stats[0].Failed, int64(1))
+	requireEqual(t, stats[1].Available, true)
+
+	// the next packets go directly to the secondary.
+	requireNoError(t, client.deliver(datagrams, 1))
+	requireEqual(t, received(t, secondary), 1)
+	requireEqual(t, client.CollectorStats()[0].Failed, int64(1))
+}
+
+func TestCollectorRedialDuringWrite(t *testing.T) {
+	server := listenCollector(t)
+	co := newCollector(server.LocalAddr().String())
+	defer co.close()
+
+	writing, err := co.connect()
+	requireNoError(t, err)
+
+	// another shard redials while the first one is still writing.
+	co.mu.Lock()
+	co.dialed = time.Now().Add(-2 * collectorRedialInterval)
+	co.mu.Unlock()
+	redialed, err := co.connect()
+	requireNoError(t, err)
+	requireEqual(t, redialed != writing, true)
+	co.release(redialed)
+
+	_, _, err = writing.WriteMsgUDP([]byte("EK"), nil, nil)
+	requireNoError(t, err)
+	co.release(writing)
+	_, _, err = writing.WriteMsgUDP([]byte("EK"), nil, nil)
+	requireEqual(t, errors.Is(err, net.ErrClosed), true)
+
+	requireEqual(t, received(t, server), 1)
+}
@@ -0,0 +1,374 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/eventkit"
+	"storj.io/eventkit/pb"
+	"storj.io/eventkit/utils"
+)
+
+// maxDurationSamples is the number of duration values kept per tag and
+// group to estimate the percentiles.
+const maxDurationSamples = 1024
+
+// SummarySuffix is appended to the event name of aggregated events.
+const SummarySuffix = "_summary"
+
+// Aggregate pre-aggregates high-rate events before they reach the target.
+//
+// Events are grouped by scope, name and the values of the selected tag
+// keys. At the end of every window one summary event is sent per group,
+// named <name>_summary, with:
+//
+//   - the grouping tags,
+//   - count: the number of aggregated events,
+//   - window: the length of the aggregation window,
+//   - <key>_sum, <key>_min, <key>_max for every int64 and float64 tag,
+//   - <key>_min, <key>_max, <key>_p50, <key>_p90, <key>_p99 for every duration tag,
+//   - <key> with the merged histogram for every histogram tag.
+//
+// Other tags are dropped. The first exemplars events of every group and
+// window are sent to the target unmodified.
+type Aggregate struct {
+	target    eventkit.Destination
+	window    time.Duration
+	exemplars int
+	keys      []string
+
+	mu          sync.Mutex
+	windowStart time.Time
+	groups      map[string]*aggregateGroup
+	pending     int
+
+	stats eventkit.StatsCounter
+}
+
+var _ eventkit.Destination = &Aggregate{}
+var _ eventkit.StatsReporter = &Aggregate{}
+
+// NewAggregate creates a destination which aggregates the events per scope, name and the values of the tags with the
+// given keys, and sends summaries to target once per window.
+func NewAggregate(target eventkit.Destination, window time.Duration, exemplars int, keys ...string) *Aggregate {
+	return &Aggregate{
+		target:      target,
+		window:      window,
+		exemplars:   exemplars,
+		keys:        keys,
+		windowStart: time.Now(),
+		groups:      map[string]*aggregateGroup{},
+	}
+}
+
+// Submit implements eventkit.Destination.
+func (a *Aggregate) Submit(events ...*eventkit.Event) {
+	var exemplars []*eventkit.Event
+
+	a.mu.Lock()
+	for _, e := range events {
+		key := a.groupKey(e)
+		g, ok := a.groups[key]
+		if !ok {
+			g = newAggregateGroup(e, a.keys)
+			a.groups[key] = g
+		}
+		if g.count < a.exemplars {
+			exemplars = append(exemplars, e)
+		}
+		g.add(e, a.keys)
+	}
+	a.pending += len(events)
+	a.mu.Unlock()
+
+	if len(exemplars) > 0 {
+		a.target.Submit(exemplars...)
+		a.stats.Sent(len(exemplars))
+	}
+}
+
+// Stats implements eventkit.StatsReporter. Queued is the number of
+// events aggregated in the current window.
+func (a *Aggregate) Stats() eventkit.Stats {
+	a.mu.Lock()
+	pending := a.pending
+	a.mu.Unlock()
+	return a.stats.Snapshot(pending)
+}
+
+// Run implements eventkit.Destination.
+func (a *Aggregate) Run(ctx context.Context) {
+	ticker := utils.NewJitteredTicker(a.window)
+	var background errgroup.Group
+	defer func() { _ = background.Wait() }()
+	background.Go(func() error {
+		a.target.Run(ctx)
+		return nil
+	})
+	background.Go(func() error {
+		ticker.Run(ctx)
+		return nil
+	})
+
+	for {
+		select {
+		case <-ticker.C:
+			a.flush()
+		case <-ctx.Done():
+			a.flush()
+			return
+		}
+	}
+}
+
+// flush sends out the summaries of the current window and starts a new one.
+func (a *Aggregate) flush() {
+	a.mu.Lock()
+	groups := a.groups
+	start := a.windowStart
+	a.groups = map[string]*aggregateGroup{}
+	a.windowStart = time.Now()
+	a.pending = 0
+	a.mu.Unlock()
+
+	if len(groups) == 0 {
+		return
+	}
+
+	window := time.Since(start)
+	summaries := make([]*eventkit.Event, 0, len(groups))
+	for _, g := range groups {
+		summaries = append(summaries, g.summary(start, window))
+	}
+	mon.Counter("aggregate_summaries").Inc(int64(len(summaries)))
+	a.target.Submit(summaries...)
+	a.stats.Sent(len(summaries))
+}
+
+func (a *Aggregate) groupKey(e *eventkit.Event) string {
+	var b strings.Builder
+	for _, s := range e.Scope {
+		b.WriteString(s)
+		b.WriteByte(0)
+	}
+	b.WriteByte(0)
+	b.WriteString(e.Name)
+	for _, key := range a.keys {
+		b.WriteByte(0)
+		if tag := findTag(e.Tags, key); tag != nil {
+			b.WriteByte(1)
+			b.WriteString(tag.ValueString())
+		}
+	}
+	return b.String()
+}
+
+type aggregateGroup struct {
+	name      string
+	scope     []string
+	groupTags []eventkit.Tag
+
+	count      int
+	numbers    map[string]*numberStats
+	durations  map[string]*durationStats
+	histograms map[string]*eventkit.Histogram
+}
+
+func newAggregateGroup(e *eventkit.Event, keys []string) *aggregateGroup {
+	g := &aggregateGroup{
+		name:       e.Name,
+		scope:      e.Scope,
+		numbers:    map[string]*numberStats{},
+		durations:  map[string]*durationStats{},
+		histograms: map[string]*eventkit.Histogram{},
+	}
+	for _, key := range keys {
+		if tag := findTag(e.Tags, key); tag != nil {
+			g.groupTags = append(g.groupTags, tag)
+		}
+	}
+	return g
+}
+
+func (g *aggregateGroup) add(e *eventkit.Event, keys []string) {
+	g.count++
+	for _, tag := range e.Tags {
+		if slices.Contains(keys, tag.Key) {
+			continue
+		}
+		switch v := tag.Value.(type) {
+		case *pb.Tag_Int64:
+			g.number(tag.Key).addInt(v.Int64)
+		case *pb.Tag_Double:
+			g.number(tag.Key).addFloat(v.Double)
+		case *pb.Tag_DurationNs:
+			s, ok := g.durations[tag.Key]
+			if !ok {
+				s = &durationStats{}
+				g.durations[tag.Key] = s
+			}
+			s.add(time.Duration(v.DurationNs))
+		case *pb.Tag_Histogram:
+			h, ok := g.histograms[tag.Key]
+			if !ok {
+				h = &eventkit.Histogram{}
+				g.histograms[tag.Key] = h
+			}
+			h.Merge(v.Histogram)
+		}
+	}
+}
+
+func (g *aggregateGroup) number(key string) *numberStats {
+	s, ok := g.numbers[key]
+	if !ok {
+		s = &numberStats{}
+		g.numbers[key] = s
+	}
+	return s
+}
+
+func (g *aggregateGroup) summary(start time.Time, window time.Duration) *eventkit.Event {
+	tags := append([]eventkit.Tag(nil), g.groupTags...)
+	tags = append(tags,
+		eventkit.Int64("count", int64(g.count)),
+		eventkit.Duration("window", window))
+
+	for _, key := range sortedKeys(g.numbers) {
+		tags = append(tags, g.numbers[key].tags(key)...)
+	}
+	for _, key := range sortedKeys(g.durations) {
+		tags = append(tags, g.durations[key].tags(key)...)
+	}
+	for _, key := range sortedKeys(g.histograms) {
+		tags = append(tags, g.histograms[key].Tag(key))
+	}
+
+	return &eventkit.Event{
+		Name:      g.name + SummarySuffix,
+		Scope:     g.scope,
+		Timestamp: start,
+		Tags:      tags,
+	}
+}
+
+// numberStats keeps int64 values as integers, until the first float64
+// value is seen for the same key.
+type numberStats struct {
+	initialized, float bool
+	isum, imin, imax   int64
+	fsum, fmin, fmax   float64
+}
+
+func (s *numberStats) addInt(v int64) {
+	if s.float {
+		s.addFloat(float64(v))
+		return
+	}
+	if !s.initialized {
+		s.imin, s.imax = v, v
+		s.initialized = true
+	}
+	s.isum += v
+	s.imin = min(s.imin, v)
+	s.imax = max(s.imax, v)
+}
+
+func (s *numberStats) addFloat(v float64) {
+	if !s.float {
+		s.float = true
+		if s.initialized {
+			s.fsum, s.fmin, s.fmax = float64(s.isum), float64(s.imin), float64(s.imax)
+		} else {
+			s.fmin, s.fmax = v, v
+			s.initialized = true
+		}
+	}
+	s.fsum += v
+	s.fmin = min(s.fmin, v)
+	s.fmax = max(s.fmax, v)
+}
+
+func (s *numberStats) tags(key string) []eventkit.Tag {
+	if s.float {
+		return []eventkit.Tag{
+			eventkit.Float64(key+"_sum", s.fsum),
+			eventkit.Float64(key+"_min", s.fmin),
+			eventkit.Float64(key+"_max", s.fmax),
+		}
+	}
+	return []eventkit.Tag{
+		eventkit.Int64(key+"_sum", s.isum),
+		eventkit.Int64(key+"_min", s.imin),
+		eventkit.Int64(key+"_max", s.imax),
+	}
+}
+
+// durationStats keeps a uniform sample of the durations with reservoir
+// sampling, and the exact minimum and maximum.
+type durationStats struct {
+	seen     int
+	min, max time.Duration
+	samples  []time.Duration
+}
+
+func (s *durationStats) add(d time.Duration) {
+	if s.seen == 0 {
+		s.min, s.max = d, d
+	}
+	s.min = min(s.min, d)
+	s.max = max(s.max, d)
+	s.seen++
+
+	if len(s.samples) < maxDurationSamples {
+		s.samples = append(s.samples, d)
+		return
+	}
+	if i := rand.IntN(s.seen); i < maxDurationSamples {
+		s.samples[i] = d
+	}
+}
+
+func (s *durationStats) tags(key string) []eventkit.Tag {
+	slices.Sort(s.samples)
+	return []eventkit.Tag{
+		eventkit.Duration(key+"_min", s.min),
+		eventkit.Duration(key+"_max", s.max),
+		eventkit.Duration(key+"_p50", s.percentile(0.50)),
+		eventkit.Duration(key+"_p90", s.percentile(0.90)),
+		eventkit.Duration(key+"_p99", s.percentile(0.99)),
+	}
+}
+
+// percentile expects the samples to be sorted.
+func (s *durationStats) percentile(p float64) time.Duration {
+	return s.samples[int(p*float64(len(s.samples)-1)+0.5)]
+}
+
+func findTag(tags []eventkit.Tag, key string) eventkit.Tag {
+	for _, tag := range tags {
+		if tag.Key == key {
+			return tag
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit"
+	"storj.io/eventkit/pb"
+)
+
+func TestAggregate(t *testing.T) {
+	m := &mockDestination{}
+	a := NewAggregate(m, time.Hour, 1, "bucket")
+
+	for i := range 100 {
+		bucket := "a"
+		if i%4 == 0 {
+			bucket = "b"
+		}
+		a.Submit(&eventkit.Event{
+			Name:  "upload",
+			Scope: []string{"app"},
+			Tags: []eventkit.Tag{
+				eventkit.String("bucket", bucket),
+				eventkit.Int64("size", int64(i)),
+				eventkit.Duration("duration", time.Duration(i+1)*time.Millisecond),
+				eventkit.String("dropped", "x"),
+			},
+		})
+	}
+
+	// one exemplar per group.
+	require.Equal(t, 2, m.Len())
+	require.Equal(t, "upload", m.events[0][0].Name)
+
+	a.flush()
+	require.Equal(t, 3, m.Len())
+
+	summaries := map[string]map[string]*pb.Tag{}
+	for _, e := range m.events[2] {
+		require.Equal(t, "upload"+SummarySuffix, e.Name)
+		require.Equal(t, []string{"app"}, e.Scope)
+		tags := map[string]*pb.Tag{}
+		for _, tag := range e.Tags {
+			tags[tag.Key] = tag
+		}
+		require.NotContains(t, tags, "dropped")
+		summaries[tags["bucket"].ValueString()] = tags
+	}
+	require.Len(t, summaries, 2)
+
+	a75 := summaries["a"]
+	require.Equal(t, "75", a75["count"].ValueString())
+	require.Equal(t, "1", a75["size_min"].ValueString())
+	require.Equal(t, "99", a75["size_max"].ValueString())
+	require.Equal(t, "3750", a75["size_sum"].ValueString())
+	require.Equal(t, (2 * time.Millisecond).String(), a75["duration_min"].ValueString())
+	require.Equal(t, (100 * time.Millisecond).String(), a75["duration_max"].ValueString())
+	require.Equal(t, (51 * time.Millisecond).String(), a75["duration_p50"].ValueString())
+
+	require.Equal(t, "25", summaries["b"]["count"].ValueString())
+
+	// the next window starts empty.
+	a.flush()
+	require.Equal(t, 3, m.Len())
+}
+
+func TestAggregateMixedNumbers(t *testing.T) {
+	m := &mockDestination{}
+	a := NewAggregate(m, time.Hour, 0)
+	a.Submit(
+		&eventkit.Event{Name: "e", Tags: []eventkit.Tag{eventkit.Int64("v", 2)}},
+		&eventkit.Event{Name: "e", Tags: []eventkit.Tag{eventkit.Float64("v", 0.5)}},
+	)
+	require.Equal(t, 0, m.Len())
+	a.flush()
+	require.Equal(t, 1, m.Len())
+
+	tags := map[string]string{}
+	for _, tag := range m.events[0][0].Tags {
+		tags[tag.Key] = tag.ValueString()
+	}
+	require.Equal(t, map[string]string{
+		"count":  "2",
+		"window": tags["window"],
+		"v_sum":  "2.5",
+		"v_min":  "0.5",
+		"v_max":  "2",
+	}, tags)
+}
+
+func TestAggregateHistograms(t *testing.T) {
+	m := &mockDestination{}
+	a := NewAggregate(m, time.Hour, 0)
+
+	var first, second eventkit.Histogram
+	first.Observe(1)
+	first.Observe(4)
+	second.Observe(0)
+	second.Observe(16)
+	a.Submit(
+		&eventkit.Event{Name: "e", Tags: []eventkit.Tag{first.Tag("latency")}},
+		&eventkit.Event{Name: "e", Tags: []eventkit.Tag{second.Tag("latency")}},
+	)
+	a.flush()
+	require.Equal(t, 1, m.Len())
+
+	var merged *pb.Histogram
+	for _, tag := range m.events[0][0].Tags {
+		if h, ok := tag.Value.(*pb.Tag_Histogram); ok {
+			require.Equal(t, "latency", tag.Key)
+			merged = h.Histogram
+		}
+	}
+	require.NotNil(t, merged)
+	require.Equal(t, uint64(4), merged.Count)
+	require.Equal(t, 21.0, merged.Sum)
+	require.Equal(t, 0.0, merged.Min)
+	require.Equal(t, 16.0, merged.Max)
+	require.Equal(t, uint64(1), merged.ZeroCount)
+	require.Len(t, merged.Buckets, 3)
+}
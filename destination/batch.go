@@ -2,6 +2,7 @@ package destination
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,30 +16,82 @@ import (
 var mon = monkit.Package()
 
 // BatchQueue collects events and send them in batches.
+//
+// By default all events are collected in one batch, which is flushed when it reaches the batch size. Optionally
+// batches can also be limited by their encoded size (WithMaxBytes), and events can be partitioned to separate
+// batches by a key (WithPartition), which are flushed independently.
+//
+// The events are queued separately by their priority, and the queues are drained by strict priority, unless
+// WithPriorityWeights is used.
 type BatchQueue struct {
-	batchThreshold int
-	flushInterval  time.Duration
-	submitQueue    chan *eventkit.Event
-	target         eventkit.Destination
-	mu             sync.Mutex
-	events         []*eventkit.Event
+	batchThreshold  int
+	maxBytes        int
+	partition       func(*eventkit.Event) string
+	flushInterval   time.Duration
+	priorityWeights eventkit.PriorityWeights
+	submitQueues    [eventkit.NumPriorities]chan *eventkit.Event
+	target          eventkit.Destination
+	mu              sync.Mutex
+	batches         map[string]*eventBatch
+	stats           eventkit.StatsCounter
+}
+
+type eventBatch struct {
+	events []*eventkit.Event
+	bytes  int
 }
 
 var _ eventkit.Destination = &BatchQueue{}
+var _ eventkit.StatsReporter = &BatchQueue{}
 
 // NewBatchQueue creates a new batchQueue. It sends out the received events in batch. Either after the flushInterval is
-// expired or when there are more than batchSize element in the queue.
+// expired or when there are more than batchSize element in the queue. Every priority has its own queue of queueSize
+// events.
 func NewBatchQueue(target eventkit.Destination, queueSize int, batchSize int, flushInterval time.Duration) *BatchQueue {
 	c := &BatchQueue{
-		submitQueue:    make(chan *eventkit.Event, queueSize),
 		batchThreshold: batchSize,
-		events:         make([]*eventkit.Event, 0),
+		batches:        map[string]*eventBatch{},
 		flushInterval:  flushInterval,
 		target:         target,
 	}
+	for class := range c.submitQueues {
+		c.submitQueues[class] = make(chan *eventkit.Event, queueSize)
+	}
+	return c
+}
+
+// WithMaxBytes limits the batches to maxBytes, measured by the encoded size of the events. A batch is flushed before
+// an event which would make it exceed the limit. A single event larger than the limit is sent in its own batch.
+//
+// It must be called before Run.
+func (c *BatchQueue) WithMaxBytes(maxBytes int) *BatchQueue {
+	c.maxBytes = maxBytes
+	return c
+}
+
+// WithPartition collects the events with different keys in separate batches. The batch size and byte limits apply
+// to every batch individually.
+//
+// It must be called before Run.
+func (c *BatchQueue) WithPartition(key func(*eventkit.Event) string) *BatchQueue {
+	c.partition = key
 	return c
 }
 
+// WithPriorityWeights drains the queues of the priorities in proportion to the weights, instead of strict priority.
+//
+// It must be called before Run.
+func (c *BatchQueue) WithPriorityWeights(weights eventkit.PriorityWeights) *BatchQueue {
+	c.priorityWeights = weights
+	return c
+}
+
+// TablePartition is a partition key for WithPartition, which groups the events by scope and name, the same way they
+// are grouped into tables by eventkitd and the BigQuery destinations.
+func TablePartition(e *eventkit.Event) string {
+	return strings.Join(e.Scope, "\x00") + "\x00\x00" + e.Name
+}
+
 // Run implements Destination.
 //
 // Once it's called and exited, it must not be called again.
@@ -47,7 +100,9 @@ func (c *BatchQueue) Run(ctx context.Context) {
 	var background errgroup.Group
 	defer func() {
 		_ = background.Wait()
-		close(c.submitQueue)
+		for _, queue := range c.submitQueues {
+			close(queue)
+		}
 	}()
 	background.Go(func() error {
 		c.target.Run(ctx)
@@ -58,45 +113,107 @@ func (c *BatchQueue) Run(ctx context.Context) {
 		return nil
 	})
 
-	sendAndReset := func() {
-		c.mu.Lock()
-		eventsToSend := c.events
-		c.events = make([]*eventkit.Event, 0, c.batchThreshold)
-		c.mu.Unlock()
+	send := func(batches [][]*eventkit.Event) {
+		for _, events := range batches {
+			c.target.Submit(events...)
+			c.stats.Sent(len(events))
+		}
+	}
 
-		c.target.Submit(eventsToSend...)
+	drainer := eventkit.NewPriorityDrainer(c.priorityWeights)
+	waiting := func(class int) bool { return len(c.submitQueues[class]) > 0 }
+	shutdown := func() {
+		var left int
+		for _, queue := range c.submitQueues {
+			left += len(queue)
+		}
+		for range left {
+			class, ok := drainer.Next(waiting)
+			if !ok {
+				break
+			}
+			send(c.addEvent(<-c.submitQueues[class]))
+		}
+		send(c.takeAll())
 	}
 
 	for {
-		select {
-		case em := <-c.submitQueue:
-			if c.addEvent(em) {
-				sendAndReset()
+		// only Run receives from the queues, so a waiting event can't be taken by anybody else.
+		if class, ok := drainer.Next(waiting); ok {
+			send(c.addEvent(<-c.submitQueues[class]))
+			select {
+			case <-ticker.C:
+				send(c.takeAll())
+			case <-ctx.Done():
+				shutdown()
+				return
+			default:
 			}
+			continue
+		}
+
+		// the select waits on every priority class, this fails to compile when their number changes.
+		_ = [1]struct{}{}[eventkit.NumPriorities-3]
+		select {
+		case em := <-c.submitQueues[0]:
+			send(c.addEvent(em))
+		case em := <-c.submitQueues[1]:
+			send(c.addEvent(em))
+		case em := <-c.submitQueues[2]:
+			send(c.addEvent(em))
 		case <-ticker.C:
-			if len(c.events) > 0 {
-				sendAndReset()
-			}
+			send(c.takeAll())
 		case <-ctx.Done():
-			left := len(c.submitQueue)
-			for range left {
-				if c.addEvent(<-c.submitQueue) {
-					sendAndReset()
-				}
-			}
-			if len(c.events) > 0 {
-				c.target.Submit(c.events...)
-			}
+			shutdown()
 			return
 		}
 	}
 }
 
-func (c *BatchQueue) addEvent(ev *eventkit.Event) (full bool) {
+// addEvent adds the event to its batch, and returns the batches which are ready to be sent.
+func (c *BatchQueue) addEvent(ev *eventkit.Event) (ready [][]*eventkit.Event) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.events = append(c.events, ev)
-	return len(c.events) >= c.batchThreshold
+
+	var key string
+	if c.partition != nil {
+		key = c.partition(ev)
+	}
+	b, ok := c.batches[key]
+	if !ok {
+		b = &eventBatch{events: make([]*eventkit.Event, 0, c.batchThreshold)}
+		c.batches[key] = b
+	}
+
+	var size int
+	if c.maxBytes > 0 {
+		size = eventSize(ev)
+		if len(b.events) > 0 && b.bytes+size > c.maxBytes {
+			ready = append(ready, b.events)
+			b.events, b.bytes = make([]*eventkit.Event, 0, c.batchThreshold), 0
+		}
+	}
+
+	b.events = append(b.events, ev)
+	b.bytes += size
+	if len(b.events) >= c.batchThreshold || (c.maxBytes > 0 && b.bytes >= c.maxBytes) {
+		ready = append(ready, b.events)
+		delete(c.batches, key)
+	}
+	return ready
+}
+
+// takeAll removes and returns all non-empty batches.
+func (c *BatchQueue) takeAll() (ready [][]*eventkit.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, b := range c.batches {
+		if len(b.events) > 0 {
+			ready = append(ready, b.events)
+		}
+		delete(c.batches, key)
+	}
+	return ready
 }
 
 // Submit implements Destination.
@@ -108,9 +225,24 @@ func (c *BatchQueue) Submit(events ...*eventkit.Event) {
 	defer mon.Task()(nil)(nil)
 	for _, e := range events {
 		select {
-		case c.submitQueue <- e:
+		case c.submitQueues[e.Priority.Class()] <- e:
 		default:
 			mon.Counter("dropped_events").Inc(1)
+			c.stats.DroppedPriority(e.Priority, 1)
 		}
 	}
 }
+
+// Stats implements eventkit.StatsReporter.
+func (c *BatchQueue) Stats() eventkit.Stats {
+	c.mu.Lock()
+	var queued int
+	for _, b := range c.batches {
+		queued += len(b.events)
+	}
+	c.mu.Unlock()
+	for _, queue := range c.submitQueues {
+		queued += len(queue)
+	}
+	return c.stats.Snapshot(queued)
+}
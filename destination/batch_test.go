@@ -5,6 +5,7 @@ package destination
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -33,6 +34,82 @@ func TestBatchQueue(t *testing.T) {
 	require.Len(t, m.events[1], 10)
 }
 
+func TestBatchQueueMaxBytes(t *testing.T) {
+	m := &mockDestination{}
+	event := &eventkit.Event{Name: "foobar"}
+	size := eventSize(event)
+	queue := NewBatchQueue(m, 1000, 100, time.Hour).WithMaxBytes(3*size + size/2)
+
+	for range 7 {
+		for _, batch := range queue.addEvent(event) {
+			m.Submit(batch...)
+		}
+	}
+	require.Equal(t, 2, m.Len())
+	require.Len(t, m.events[0], 3)
+	require.Len(t, m.events[1], 3)
+	require.Equal(t, int64(1), queue.Stats().Queued)
+
+	// events larger than the limit are sent alone.
+	large := &eventkit.Event{Name: "large", Tags: []eventkit.Tag{eventkit.String("data", strings.Repeat("x", 10*size))}}
+	ready := queue.addEvent(large)
+	require.Len(t, ready, 2)
+	require.Len(t, ready[0], 1)
+	require.Equal(t, []*eventkit.Event{large}, ready[1])
+}
+
+func TestBatchQueuePartition(t *testing.T) {
+	m := &mockDestination{}
+	ctx := t.Context()
+	queue := NewBatchQueue(m, 1000, 3, time.Hour).WithPartition(TablePartition)
+	go func() {
+		queue.Run(ctx)
+	}()
+	for i := range 8 {
+		name := "a"
+		if i%2 == 1 {
+			name = "b"
+		}
+		queue.Submit(&eventkit.Event{Name: name, Scope: []string{"scope"}})
+	}
+	require.Eventually(t, func() bool {
+		return m.Len() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	for _, batch := range m.events {
+		require.Len(t, batch, 3)
+		for _, e := range batch {
+			require.Equal(t, batch[0].Name, e.Name)
+		}
+	}
+	require.NotEqual(t, m.events[0][0].Name, m.events[1][0].Name)
+	require.Equal(t, int64(2), queue.Stats().Queued)
+}
+
+func TestBatchQueuePriorities(t *testing.T) {
+	m := &mockDestination{}
+	queue := NewBatchQueue(m, 2, 100, time.Hour)
+	for _, name := range []string{"d1", "d2", "d3"} {
+		queue.Submit(&eventkit.Event{Name: name, Priority: eventkit.PriorityDebug})
+	}
+	queue.Submit(&eventkit.Event{Name: "n"}, &eventkit.Event{Name: "c", Priority: eventkit.PriorityCritical})
+
+	stats := queue.Stats()
+	require.Equal(t, int64(4), stats.Queued)
+	require.Equal(t, int64(1), stats.Dropped)
+	require.Equal(t, map[string]int64{"debug": 1}, stats.DroppedByPriority)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	queue.Run(ctx)
+
+	require.Equal(t, 1, m.Len())
+	var names []string
+	for _, e := range m.events[0] {
+		names = append(names, e.Name)
+	}
+	require.Equal(t, []string{"c", "n", "d1", "d2"}, names)
+}
+
 type mockDestination struct {
 	mu     sync.Mutex
 	events [][]*eventkit.Event
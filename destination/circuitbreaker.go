@@ -0,0 +1,221 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/eventkit"
+)
+
+const (
+	defaultCircuitFailureThreshold = 5
+	defaultCircuitOpenTimeout      = 30 * time.Second
+	defaultCircuitHalfOpenProbes   = 1
+)
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed sends the events to the target.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen sends the events to the fallback.
+	CircuitOpen
+	// CircuitHalfOpen sends one batch at a time to the target to probe it, the rest to the fallback.
+	CircuitHalfOpen
+)
+
+// String implements fmt.Stringer.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerOptions configures CircuitBreaker. Zero values are replaced with the defaults.
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures which opens the circuit.
+	FailureThreshold int
+	// OpenTimeout is the time the circuit stays open before probing the target again.
+	OpenTimeout time.Duration
+	// HalfOpenProbes is the number of successful probes required to close the circuit.
+	HalfOpenProbes int
+}
+
+// CircuitBreaker stops sending events to a failing target, and sends them to a fallback destination instead.
+//
+// After FailureThreshold consecutive failures the circuit opens: events go directly to the fallback, without
+// calling the target. After OpenTimeout the circuit becomes half-open, and batches are sent to the target one at a
+// time as probes. HalfOpenProbes successful probes close the circuit, a failed probe opens it again.
+//
+// Batches which fail while the circuit is closed are also sent to the fallback. Without a fallback, events which
+// can't be sent to the target are dropped.
+//
+// State transitions are reported with the circuit_breaker_transitions monkit counter (tagged with the new state),
+// and the circuit_breaker_state monkit value.
+type CircuitBreaker struct {
+	target   eventkit.Sender
+	fallback eventkit.Destination
+	opts     CircuitBreakerOptions
+
+	mu        sync.Mutex
+	state     CircuitState
+	failures  int
+	successes int
+	openedAt  time.Time
+	probing   bool
+
+	stats eventkit.StatsCounter
+}
+
+var _ eventkit.Destination = &CircuitBreaker{}
+var _ eventkit.StatsReporter = &CircuitBreaker{}
+
+// NewCircuitBreaker creates a destination which protects target with a circuit breaker. fallback may be nil.
+func NewCircuitBreaker(target eventkit.Sender, fallback eventkit.Destination, opts CircuitBreakerOptions) *CircuitBreaker {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = defaultCircuitFailureThreshold
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = defaultCircuitOpenTimeout
+	}
+	if opts.HalfOpenProbes <= 0 {
+		opts.HalfOpenProbes = defaultCircuitHalfOpenProbes
+	}
+	return &CircuitBreaker{
+		target:   target,
+		fallback: fallback,
+		opts:     opts,
+	}
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// Submit implements eventkit.Destination.
+func (cb *CircuitBreaker) Submit(events ...*eventkit.Event) {
+	probe, ok := cb.acquire()
+	if !ok {
+		cb.sendToFallback(events)
+		return
+	}
+
+	err := cb.target.Send(events...)
+	cb.release(probe, err)
+	if err != nil {
+		cb.stats.Failed(len(events), err)
+		cb.sendToFallback(events)
+		return
+	}
+	cb.stats.Sent(len(events))
+}
+
+// acquire decides whether the events can be sent to the target. probe is true, when the send is a half-open probe.
+func (cb *CircuitBreaker) acquire() (probe, ok bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitClosed:
+		return false, true
+	case CircuitOpen:
+		if time.Since(cb.openedAt) < cb.opts.OpenTimeout {
+			return false, false
+		}
+		cb.transition(CircuitHalfOpen)
+	}
+
+	if cb.probing {
+		return false, false
+	}
+	cb.probing = true
+	return true, true
+}
+
+// release updates the state with the result of a send.
+func (cb *CircuitBreaker) release(probe bool, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if probe {
+		cb.probing = false
+		if err != nil {
+			cb.transition(CircuitOpen)
+			return
+		}
+		cb.successes++
+		if cb.successes >= cb.opts.HalfOpenProbes {
+			cb.transition(CircuitClosed)
+		}
+		return
+	}
+
+	if err == nil {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.state == CircuitClosed && cb.failures >= cb.opts.FailureThreshold {
+		cb.transition(CircuitOpen)
+	}
+}
+
+// transition must be called with mu held.
+func (cb *CircuitBreaker) transition(state CircuitState) {
+	cb.state = state
+	cb.failures = 0
+	cb.successes = 0
+	if state == CircuitOpen {
+		cb.openedAt = time.Now()
+	}
+	mon.Counter("circuit_breaker_transitions", monkit.NewSeriesTag("state", state.String())).Inc(1)
+	mon.IntVal("circuit_breaker_state").Observe(int64(state))
+}
+
+func (cb *CircuitBreaker) sendToFallback(events []*eventkit.Event) {
+	if cb.fallback == nil {
+		mon.Counter("dropped_events").Inc(int64(len(events)))
+		cb.stats.Dropped(len(events))
+		return
+	}
+	cb.fallback.Submit(events...)
+}
+
+// Run implements eventkit.Destination.
+func (cb *CircuitBreaker) Run(ctx context.Context) {
+	var background errgroup.Group
+	background.Go(func() error {
+		cb.target.Run(ctx)
+		return nil
+	})
+	if cb.fallback != nil {
+		background.Go(func() error {
+			cb.fallback.Run(ctx)
+			return nil
+		})
+	}
+	_ = background.Wait()
+}
+
+// Stats implements eventkit.StatsReporter. Sent and Failed count the events sent to the target.
+func (cb *CircuitBreaker) Stats() eventkit.Stats {
+	return cb.stats.Snapshot(0)
+}
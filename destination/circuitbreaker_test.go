@@ -0,0 +1,69 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	down := errors.New("down")
+	target := &failingSender{failures: []error{down, down, down}}
+	fallback := &mockDestination{}
+	cb := NewCircuitBreaker(target, fallback, CircuitBreakerOptions{
+		FailureThreshold: 2,
+		OpenTimeout:      50 * time.Millisecond,
+	})
+
+	event := &eventkit.Event{Name: "a"}
+
+	// failures below the threshold go to the fallback, the circuit stays closed.
+	cb.Submit(event)
+	require.Equal(t, CircuitClosed, cb.State())
+	require.Equal(t, 1, fallback.Len())
+
+	cb.Submit(event)
+	require.Equal(t, CircuitOpen, cb.State())
+	require.Equal(t, 2, fallback.Len())
+
+	// the target is not called while open.
+	cb.Submit(event)
+	require.Equal(t, 2, target.Attempts())
+	require.Equal(t, 3, fallback.Len())
+
+	// a failing probe opens the circuit again.
+	time.Sleep(60 * time.Millisecond)
+	cb.Submit(event)
+	require.Equal(t, 3, target.Attempts())
+	require.Equal(t, CircuitOpen, cb.State())
+	require.Equal(t, 4, fallback.Len())
+
+	// a successful probe closes it.
+	time.Sleep(60 * time.Millisecond)
+	cb.Submit(event)
+	require.Equal(t, 4, target.Attempts())
+	require.Equal(t, CircuitClosed, cb.State())
+	require.Equal(t, 1, target.Len())
+	require.Equal(t, 4, fallback.Len())
+
+	stats := cb.Stats()
+	require.Equal(t, int64(1), stats.Sent)
+	require.Equal(t, int64(3), stats.Failed)
+}
+
+func TestCircuitBreakerWithoutFallback(t *testing.T) {
+	target := &failingSender{failures: []error{errors.New("down")}}
+	cb := NewCircuitBreaker(target, nil, CircuitBreakerOptions{FailureThreshold: 1, OpenTimeout: time.Hour})
+
+	cb.Submit(&eventkit.Event{Name: "a"})
+	cb.Submit(&eventkit.Event{Name: "b"})
+	require.Equal(t, CircuitOpen, cb.State())
+	require.Equal(t, int64(2), cb.Stats().Dropped)
+}
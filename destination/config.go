@@ -0,0 +1,215 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"storj.io/eventkit"
+)
+
+const (
+	defaultConfigQueueSize     = 1000
+	defaultConfigBatchSize     = 100
+	defaultConfigFlushInterval = 10 * time.Second
+	defaultConfigWorkers       = 1
+)
+
+func init() {
+	eventkit.RegisterDestinationType(eventkit.DestinationType{
+		Name:         "file",
+		Params:       []string{"path", "application", "version", "instance", "closeInterval"},
+		DefaultParam: "path",
+		Create:       createFile,
+	})
+	eventkit.RegisterDestinationType(eventkit.DestinationType{
+		Name:         "stdout",
+		Params:       []string{"format", "color", "queueSize"},
+		DefaultParam: "format",
+		Create:       createStdout,
+	})
+	eventkit.RegisterDestinationType(eventkit.DestinationType{
+		Name:    "batch",
+		Params:  []string{"queueSize", "batchSize", "flushInterval", "maxBytes", "partition"},
+		Wrapper: true,
+		Create:  createBatch,
+	})
+	eventkit.RegisterDestinationType(eventkit.DestinationType{
+		Name:    "parallel",
+		Params:  []string{"workers", "minWorkers", "maxWorkers"},
+		Wrapper: true,
+		Create:  createParallel,
+	})
+	eventkit.RegisterDestinationType(eventkit.DestinationType{
+		Name:    "retry",
+		Params:  []string{"maxAttempts", "baseDelay", "maxDelay", "maxInFlightBytes"},
+		Wrapper: true,
+		Create:  createRetry,
+	})
+	eventkit.RegisterDestinationType(eventkit.DestinationType{
+		Name:         "sample",
+		Params:       []string{"rate"},
+		DefaultParam: "rate",
+		Wrapper:      true,
+		Create:       createSample,
+	})
+	eventkit.RegisterDestinationType(eventkit.DestinationType{
+		Name:    "filter",
+		Params:  []string{"scope", "name", "tag"},
+		Wrapper: true,
+		Create:  createFilter,
+	})
+}
+
+func createFile(ctx context.Context, params eventkit.LayerParams, next func() (eventkit.Destination, error)) (eventkit.Destination, error) {
+	base, found := params.Get("path")
+	if !found {
+		return nil, errors.New("path parameter is required")
+	}
+	closeInterval, err := params.Duration("closeInterval", defaultFileCloseInterval)
+	if err != nil {
+		return nil, err
+	}
+	instance, found := params.Get("instance")
+	if !found {
+		instance, _ = os.Hostname()
+	}
+	application := params.String("application", filepath.Base(os.Args[0]))
+	return NewFile(base, application, params.String("version", ""), instance).WithCloseInterval(closeInterval), nil
+}
+
+func createStdout(ctx context.Context, params eventkit.LayerParams, next func() (eventkit.Destination, error)) (eventkit.Destination, error) {
+	format, err := ParseLogFormat(params.String("format", "json"))
+	if err != nil {
+		return nil, err
+	}
+	color, err := params.Bool("color", false)
+	if err != nil {
+		return nil, err
+	}
+	queueSize, err := params.Int("queueSize", defaultLogWriterQueueSize)
+	if err != nil {
+		return nil, err
+	}
+	return NewLogWriter(os.Stdout, format).WithColor(color).WithQueueSize(queueSize), nil
+}
+
+func createBatch(ctx context.Context, params eventkit.LayerParams, next func() (eventkit.Destination, error)) (eventkit.Destination, error) {
+	queueSize, err := params.Int("queueSize", defaultConfigQueueSize)
+	if err != nil {
+		return nil, err
+	}
+	batchSize, err := params.Int("batchSize", defaultConfigBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	flushInterval, err := params.Duration("flushInterval", defaultConfigFlushInterval)
+	if err != nil {
+		return nil, err
+	}
+	maxBytes, err := params.Int("maxBytes", 0)
+	if err != nil {
+		return nil, err
+	}
+	var partition func(*eventkit.Event) string
+	switch value := params.String("partition", "none"); value {
+	case "table":
+		partition = TablePartition
+	case "none":
+	default:
+		return nil, fmt.Errorf("partition parameter should be table or none and not %s", value)
+	}
+
+	target, err := next()
+	if err != nil {
+		return nil, err
+	}
+	queue := NewBatchQueue(target, queueSize, batchSize, flushInterval).WithMaxBytes(maxBytes)
+	if partition != nil {
+		queue = queue.WithPartition(partition)
+	}
+	return queue, nil
+}
+
+func createParallel(ctx context.Context, params eventkit.LayerParams, next func() (eventkit.Destination, error)) (eventkit.Destination, error) {
+	workers, err := params.Int("workers", defaultConfigWorkers)
+	if err != nil {
+		return nil, err
+	}
+	minWorkers, err := params.Int("minWorkers", 0)
+	if err != nil {
+		return nil, err
+	}
+	maxWorkers, err := params.Int("maxWorkers", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	p := NewParallel(next, workers)
+	if minWorkers > 0 || maxWorkers > 0 {
+		p = p.WithWorkerRange(max(minWorkers, 1), max(maxWorkers, workers))
+	}
+	return p, nil
+}
+
+func createRetry(ctx context.Context, params eventkit.LayerParams, next func() (eventkit.Destination, error)) (eventkit.Destination, error) {
+	var opts RetryOptions
+	var err error
+	if opts.MaxAttempts, err = params.Int("maxAttempts", 0); err != nil {
+		return nil, err
+	}
+	if opts.BaseDelay, err = params.Duration("baseDelay", 0); err != nil {
+		return nil, err
+	}
+	if opts.MaxDelay, err = params.Duration("maxDelay", 0); err != nil {
+		return nil, err
+	}
+	if opts.MaxInFlightBytes, err = params.Int("maxInFlightBytes", 0); err != nil {
+		return nil, err
+	}
+
+	target, err := next()
+	if err != nil {
+		return nil, err
+	}
+	sender, ok := target.(eventkit.Sender)
+	if !ok {
+		return nil, errors.New("retry layer requires a destination which reports delivery errors")
+	}
+	return NewRetry(sender, opts), nil
+}
+
+func createSample(ctx context.Context, params eventkit.LayerParams, next func() (eventkit.Destination, error)) (eventkit.Destination, error) {
+	rate, err := params.Float("rate", 1)
+	if err != nil {
+		return nil, err
+	}
+	if rate < 0 || rate > 1 {
+		return nil, fmt.Errorf("rate parameter should be between 0 and 1 and not %v", rate)
+	}
+
+	target, err := next()
+	if err != nil {
+		return nil, err
+	}
+	return NewSample(target, rate), nil
+}
+
+func createFilter(ctx context.Context, params eventkit.LayerParams, next func() (eventkit.Destination, error)) (eventkit.Destination, error) {
+	filter, err := eventkit.ParseFilter(params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := next()
+	if err != nil {
+		return nil, err
+	}
+	return NewFilter(target, filter), nil
+}
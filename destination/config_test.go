@@ -0,0 +1,66 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit"
+)
+
+func init() {
+	eventkit.RegisterDestinationType(eventkit.DestinationType{
+		Name:   "mock",
+		Params: []string{"fail"},
+		Create: func(ctx context.Context, params eventkit.LayerParams, next func() (eventkit.Destination, error)) (eventkit.Destination, error) {
+			if _, found := params.Get("fail"); found {
+				return &failingSender{}, nil
+			}
+			return &mockDestination{}, nil
+		},
+	})
+}
+
+func TestCreateDestination(t *testing.T) {
+	ctx := t.Context()
+
+	dest, err := eventkit.CreateDestination(ctx, "mock|batch:queueSize=10,batchSize=5,flushInterval=1s,partition=table|parallel:minWorkers=1,maxWorkers=4")
+	require.NoError(t, err)
+	parallel, ok := dest.(*Parallel)
+	require.True(t, ok)
+	require.Equal(t, 1, parallel.minWorkers)
+	require.Equal(t, 4, parallel.maxWorkers)
+
+	dest, err = eventkit.CreateDestination(ctx, "mock|sample:0.5|filter:scope=a,scope=b,tag=env=prod")
+	require.NoError(t, err)
+	filter, ok := dest.(*Filter)
+	require.True(t, ok)
+	require.IsType(t, &Sample{}, filter.target)
+
+	dest, err = eventkit.CreateDestination(ctx, "mock:fail=1|retry:maxAttempts=3,baseDelay=1s")
+	require.NoError(t, err)
+	retry, ok := dest.(*Retry)
+	require.True(t, ok)
+	require.Equal(t, 3, retry.opts.MaxAttempts)
+
+	dest, err = eventkit.CreateDestination(ctx, "file:"+t.TempDir()+",application=app,closeInterval=1m|batch")
+	require.NoError(t, err)
+	require.IsType(t, &File{}, dest.(*BatchQueue).target)
+
+	for _, config := range []string{
+		"file:application=app",
+		"mock|retry",
+		"mock|batch:queueSize=many",
+		"mock|batch:partition=scope",
+		"mock|sample:rate=2",
+		"mock|filter:name=[",
+		"mock|parallel:runners=10",
+	} {
+		_, err := eventkit.CreateDestination(ctx, config)
+		require.Error(t, err, config)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"compress/zlib"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"storj.io/eventkit"
+	"storj.io/eventkit/eventkitd/private/path"
+	"storj.io/eventkit/eventkitd/private/protostream"
+	"storj.io/eventkit/eventkitd/private/resumablecompressed"
+	"storj.io/eventkit/pb"
+)
+
+const defaultFileCloseInterval = 15 * time.Second
+
+// File writes the events to local files, in the same layout and format as eventkitd: one file per hour, scope and
+// name under the base directory, each containing a compressed stream of pb.Record. The files can be read with the
+// eventkitd utilities, or moved to the collector host.
+//
+// The open files are closed periodically from Run, and when Run exits, so the written data is complete on the disk.
+type File struct {
+	base          string
+	application   string
+	version       string
+	instance      string
+	closeInterval time.Duration
+
+	mu      sync.Mutex
+	handles map[string]*fileHandle
+
+	stats eventkit.StatsCounter
+}
+
+type fileHandle struct {
+	writer *resumablecompressed.Writer
+	stream *protostream.Writer
+}
+
+var _ eventkit.Destination = &File{}
+var _ eventkit.StatsReporter = &File{}
+
+// NewFile creates a destination which writes the events under the base directory. application, version and
+// instance are saved to every record, as eventkitd does with the values of the packets.
+func NewFile(base, application, version, instance string) *File {
+	if !strings.HasSuffix(base, string(filepath.Separator)) {
+		base += string(filepath.Separator)
+	}
+	return &File{
+		base:          base,
+		application:   application,
+		version:       version,
+		instance:      instance,
+		closeInterval: defaultFileCloseInterval,
+		handles:       map[string]*fileHandle{},
+	}
+}
+
+// WithCloseInterval sets how often the open files are closed.
+//
+// It must be called before Run.
+func (f *File) WithCloseInterval(interval time.Duration) *File {
+	f.closeInterval = interval
+	return f
+}
+
+// Submit implements eventkit.Destination.
+func (f *File) Submit(events ...*eventkit.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, e := range events {
+		timestamp := e.Timestamp
+		if timestamp.IsZero() {
+			timestamp = time.Now()
+		}
+		record := &pb.Record{
+			Version:            pb.RecordVersion,
+			Name:               e.Name,
+			Scope:              e.Scope,
+			Received:           pb.AsTimestamp(time.Now()),
+			Application:        f.application,
+			ApplicationVersion: f.version,
+			Instance:           f.instance,
+			Timestamp:          pb.AsTimestamp(timestamp),
+			Tags:               e.Tags,
+			SessionId:          e.ID.Session,
+			Sequence:           e.ID.Sequence,
+			Severity:           e.Severity,
+		}
+		if err := f.append(path.Compute(f.base, timestamp, e.Scope, e.Name), record); err != nil {
+			mon.Counter("file_write_errors").Inc(1)
+			f.stats.Failed(1, err)
+			continue
+		}
+		f.stats.Sent(1)
+	}
+}
+
+// append must be called with mu held.
+func (f *File) append(recordPath string, record *pb.Record) error {
+	h, ok := f.handles[recordPath]
+	if !ok {
+		if err := os.MkdirAll(filepath.Dir(recordPath), 0755); err != nil {
+			return err
+		}
+		fh, err := os.OpenFile(recordPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		rcw, err := resumablecompressed.NewWriter(fh, zlib.DefaultCompression)
+		if err != nil {
+			_ = fh.Close()
+			return err
+		}
+		h = &fileHandle{
+			writer: rcw,
+			stream: protostream.NewWriter(rcw),
+		}
+		f.handles[recordPath] = h
+	}
+	return h.stream.Marshal(record)
+}
+
+// closeAll closes the open files. The next events reopen them, and continue with a new compressed segment.
+func (f *File) closeAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for recordPath, h := range f.handles {
+		delete(f.handles, recordPath)
+		if err := h.writer.Close(); err != nil {
+			f.stats.Error(err)
+		}
+	}
+}
+
+// Run implements eventkit.Destination.
+func (f *File) Run(ctx context.Context) {
+	defer f.closeAll()
+	ticker := time.NewTicker(f.closeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f.closeAll()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stats implements eventkit.StatsReporter.
+func (f *File) Stats() eventkit.Stats {
+	return f.stats.Snapshot(0)
+}
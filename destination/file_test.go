@@ -0,0 +1,90 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit"
+	"storj.io/eventkit/eventkitd/private/path"
+	"storj.io/eventkit/eventkitd/private/protostream"
+	"storj.io/eventkit/eventkitd/private/resumablecompressed"
+	"storj.io/eventkit/pb"
+)
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	f := NewFile(dir, "app", "v1", "instance1")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		f.Run(ctx)
+		close(done)
+	}()
+
+	ts := time.Date(2026, 10, 18, 13, 5, 0, 0, time.Local)
+	f.Submit(
+		&eventkit.Event{Name: "upload", Scope: []string{"storj.io", "uplink"}, Timestamp: ts,
+			Tags: []eventkit.Tag{eventkit.Int64("size", 10)}},
+		&eventkit.Event{Name: "upload", Scope: []string{"storj.io", "uplink"}, Timestamp: ts.Add(time.Minute)},
+		&eventkit.Event{Name: "download", Scope: []string{"storj.io", "uplink"}, Timestamp: ts},
+	)
+	// reopening a file after the close appends a new segment.
+	f.closeAll()
+	f.Submit(&eventkit.Event{Name: "upload", Scope: []string{"storj.io", "uplink"}, Timestamp: ts})
+	cancel()
+	<-done
+
+	records := map[string][]*pb.Record{}
+	err := filepath.WalkDir(dir, func(fpath string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		name, scope, err := path.Parse(fpath)
+		require.NoError(t, err)
+		require.Equal(t, []string{"storj.io", "uplink"}, scope)
+		require.Equal(t, path.Compute(dir+string(filepath.Separator), ts, scope, name), fpath)
+
+		fh, err := os.Open(fpath)
+		require.NoError(t, err)
+		defer func() { _ = fh.Close() }()
+		r := protostream.NewReader(resumablecompressed.NewReader(fh))
+		for {
+			var record pb.Record
+			err := r.Unmarshal(&record)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			records[name] = append(records[name], &record)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, records["upload"], 3)
+	require.Len(t, records["download"], 1)
+	first := records["upload"][0]
+	require.Equal(t, "app", first.Application)
+	require.Equal(t, "v1", first.ApplicationVersion)
+	require.Equal(t, "instance1", first.Instance)
+	require.Equal(t, uint32(pb.RecordVersion), first.Version)
+	require.Equal(t, "upload", first.Name)
+	require.Equal(t, []string{"storj.io", "uplink"}, first.Scope)
+	require.NotNil(t, first.Received)
+	require.True(t, ts.Equal(first.Timestamp.AsTime()))
+	require.Len(t, first.Tags, 1)
+	require.Equal(t, "size", first.Tags[0].Key)
+	require.Equal(t, int64(4), f.Stats().Sent)
+}
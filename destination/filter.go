@@ -0,0 +1,52 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"context"
+
+	"storj.io/eventkit"
+)
+
+// Filter forwards only the events matching a filter to the target.
+type Filter struct {
+	target eventkit.Destination
+	filter eventkit.Filter
+	stats  eventkit.StatsCounter
+}
+
+var _ eventkit.Destination = &Filter{}
+var _ eventkit.StatsReporter = &Filter{}
+
+// NewFilter creates a destination which sends the events matching filter to target, and drops the rest.
+func NewFilter(target eventkit.Destination, filter eventkit.Filter) *Filter {
+	return &Filter{
+		target: target,
+		filter: filter,
+	}
+}
+
+// Submit implements eventkit.Destination.
+func (f *Filter) Submit(events ...*eventkit.Event) {
+	matching := events[:0:0]
+	for _, e := range events {
+		if f.filter(e) {
+			matching = append(matching, e)
+		}
+	}
+	if len(matching) > 0 {
+		f.target.Submit(matching...)
+		f.stats.Sent(len(matching))
+	}
+}
+
+// Stats implements eventkit.StatsReporter.
+func (f *Filter) Stats() eventkit.Stats {
+	return f.stats.Snapshot(0)
+}
+
+// Run implements eventkit.Destination.
+func (f *Filter) Run(ctx context.Context) {
+	f.target.Run(ctx)
+}
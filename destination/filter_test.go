@@ -0,0 +1,30 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit"
+)
+
+func TestFilter(t *testing.T) {
+	m := &mockDestination{}
+	f := NewFilter(m, eventkit.HasTag("keep"))
+
+	f.Submit(&eventkit.Event{Name: "dropped"})
+	require.Equal(t, 0, m.Len())
+
+	f.Submit(
+		&eventkit.Event{Name: "a", Tags: []eventkit.Tag{eventkit.Bool("keep", true)}},
+		&eventkit.Event{Name: "b"},
+		&eventkit.Event{Name: "c", Tags: []eventkit.Tag{eventkit.Bool("keep", false)}},
+	)
+	require.Equal(t, 1, m.Len())
+	require.Len(t, m.events[0], 2)
+	require.Equal(t, "a", m.events[0][0].Name)
+	require.Equal(t, "c", m.events[0][1].Name)
+}
@@ -3,75 +3,267 @@ package destination
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"os"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"storj.io/eventkit"
+	"storj.io/eventkit/utils"
+)
+
+const (
+	parallelScaleInterval   = time.Second
+	parallelCreateBaseDelay = 100 * time.Millisecond
+	parallelCreateMaxDelay  = 30 * time.Second
 )
 
 // Parallel sends messages parallel from multiple goroutines.
+//
+// The number of workers is kept between a minimum and a maximum: a worker is added when batches are waiting in the
+// queue, and removed when the queue is empty. Workers whose destination can't be created retry with exponential
+// backoff.
 type Parallel struct {
-	queue    chan []*eventkit.Event
-	target   func() (eventkit.Destination, error)
-	workers  int
-	teardown chan struct{}
+	queue      chan []*eventkit.Event
+	target     func() (eventkit.Destination, error)
+	minWorkers int
+	maxWorkers int
+	teardown   chan struct{}
+	queued     atomic.Int64
+	stats      eventkit.StatsCounter
+
+	mu      sync.Mutex
+	workers []*parallelWorker
+	nextID  int
+}
+
+type parallelWorker struct {
+	id   int
+	stop chan struct{}
+
+	mu       sync.Mutex
+	destType string
+
+	stats eventkit.StatsCounter
 }
 
 // NewParallel creates a destination. It requires a way to create the worker destinations and the number of goroutines.
 func NewParallel(target func() (eventkit.Destination, error), workers int) *Parallel {
 	return &Parallel{
-		queue:    make(chan []*eventkit.Event, workers),
-		teardown: make(chan struct{}),
-		target:   target,
-		workers:  workers,
+		queue:      make(chan []*eventkit.Event, workers),
+		teardown:   make(chan struct{}),
+		target:     target,
+		minWorkers: workers,
+		maxWorkers: workers,
 	}
 }
 
+// WithWorkerRange lets the number of workers scale between minWorkers and maxWorkers, depending on the queue depth.
+//
+// It must be called before Run and Submit.
+func (p *Parallel) WithWorkerRange(minWorkers, maxWorkers int) *Parallel {
+	p.minWorkers = minWorkers
+	p.maxWorkers = max(minWorkers, maxWorkers)
+	p.queue = make(chan []*eventkit.Event, p.maxWorkers)
+	return p
+}
+
 // Submit implements eventkit.Destination.
 //
 // The events are sent only while `Run` is executing.
 //
 // It panics if it's called after `Run` finished.
 func (p *Parallel) Submit(events ...*eventkit.Event) {
+	p.queued.Add(int64(len(events)))
 	select {
 	case p.queue <- events:
 	case <-p.teardown:
-		mon.Counter("dropped_events").Inc(int64(len(events)))
+		p.drop(events)
 	}
 
 }
 
+func (p *Parallel) drop(events []*eventkit.Event) {
+	p.queued.Add(-int64(len(events)))
+	mon.Counter("dropped_events").Inc(int64(len(events)))
+	p.stats.Dropped(len(events))
+}
+
 // Run implements eventkit.Destination.
 //
+// When ctx is canceled, the workers send out the batches left in the queue before their destinations are stopped.
+//
 // Once it's called and exited, it must not be called again.
 func (p *Parallel) Run(ctx context.Context) {
-	w := errgroup.Group{}
-	for i := 0; i < p.workers; i++ {
-		dest, err := p.target()
-		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "WARNING: eventkit destination couldn't be created: %v", err)
-			continue
+	// the destinations are stopped by the workers, after the queue is drained.
+	destCtx := context.WithoutCancel(ctx)
+
+	var w errgroup.Group
+	for range p.minWorkers {
+		p.startWorker(ctx, destCtx, &w)
+	}
+
+	ticker := time.NewTicker(parallelScaleInterval)
+	defer ticker.Stop()
+
+scale:
+	for {
+		select {
+		case <-ticker.C:
+			p.scale(ctx, destCtx, &w)
+		case <-ctx.Done():
+			break scale
 		}
-		w.Go(func() error {
-			dest.Run(ctx)
-			return nil
-		})
-		w.Go(func() error {
+	}
+
+	close(p.teardown)
+	_ = w.Wait()
+
+	// batches left when no worker could create a destination.
+	for len(p.queue) > 0 {
+		p.drop(<-p.queue)
+	}
+	close(p.queue)
+
+}
+
+// scale adds a worker when batches are waiting, or removes one when the queue is empty.
+func (p *Parallel) scale(ctx, destCtx context.Context, w *errgroup.Group) {
+	depth := len(p.queue)
+
+	p.mu.Lock()
+	active := len(p.workers)
+	var stopped *parallelWorker
+	if depth == 0 && active > p.minWorkers {
+		stopped = p.workers[active-1]
+		p.workers = p.workers[:active-1]
+	}
+	p.mu.Unlock()
+
+	switch {
+	case stopped != nil:
+		close(stopped.stop)
+		active--
+	case depth > 0 && active < p.maxWorkers:
+		p.startWorker(ctx, destCtx, w)
+		active++
+	}
+	mon.IntVal("parallel_workers").Observe(int64(active))
+}
+
+func (p *Parallel) startWorker(ctx, destCtx context.Context, w *errgroup.Group) {
+	p.mu.Lock()
+	worker := &parallelWorker{id: p.nextID, stop: make(chan struct{})}
+	p.nextID++
+	p.workers = append(p.workers, worker)
+	p.mu.Unlock()
+
+	w.Go(func() error {
+		p.runWorker(ctx, destCtx, worker, w)
+		return nil
+	})
+}
+
+func (p *Parallel) runWorker(ctx, destCtx context.Context, worker *parallelWorker, w *errgroup.Group) {
+	dest := p.createDestination(ctx, worker)
+	if dest == nil {
+		return
+	}
+
+	destCtx, stopDest := context.WithCancel(destCtx)
+	defer stopDest()
+	w.Go(func() error {
+		dest.Run(destCtx)
+		return nil
+	})
+
+	deliver := func(events []*eventkit.Event) {
+		p.queued.Add(-int64(len(events)))
+		dest.Submit(events...)
+		p.stats.Sent(len(events))
+		worker.stats.Sent(len(events))
+	}
+
+	for {
+		select {
+		case events := <-p.queue:
+			deliver(events)
+		case <-worker.stop:
+			return
+		case <-ctx.Done():
 			for {
 				select {
 				case events := <-p.queue:
-					dest.Submit(events...)
-				case <-ctx.Done():
-					return nil
+					deliver(events)
+				default:
+					return
 				}
 			}
-		})
+		}
 	}
-	_ = w.Wait()
-	close(p.teardown)
-	close(p.queue)
+}
+
+// createDestination creates the destination of a worker, retrying with backoff. It returns nil when the worker is
+// stopped before it succeeds.
+func (p *Parallel) createDestination(ctx context.Context, worker *parallelWorker) eventkit.Destination {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	delay := parallelCreateBaseDelay
+	for {
+		dest, err := p.target()
+		if err == nil {
+			worker.mu.Lock()
+			worker.destType = fmt.Sprintf("%T", dest)
+			worker.mu.Unlock()
+			return dest
+		}
+
+		_, _ = fmt.Fprintf(os.Stderr, "WARNING: eventkit destination couldn't be created, retrying: %v\n", err)
+		mon.Counter("worker_create_errors").Inc(1)
+		p.stats.Error(err)
+		worker.stats.Error(err)
+
+		timer := time.NewTimer(utils.Jitter(rng, delay))
+		select {
+		case <-timer.C:
+		case <-worker.stop:
+			timer.Stop()
+			return nil
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		}
+		delay = min(2*delay, parallelCreateMaxDelay)
+	}
+}
+
+// Stats implements eventkit.StatsReporter.
+func (p *Parallel) Stats() eventkit.Stats {
+	return p.stats.Snapshot(int(p.queued.Load()))
+}
+
+// WorkerStats returns the Stats of the active workers. Index is the id of the worker, and Type is the type of its
+// destination (empty while it's being created).
+func (p *Parallel) WorkerStats() []eventkit.DestinationStats {
+	p.mu.Lock()
+	workers := append([]*parallelWorker(nil), p.workers...)
+	p.mu.Unlock()
 
+	stats := make([]eventkit.DestinationStats, 0, len(workers))
+	for _, worker := range workers {
+		worker.mu.Lock()
+		destType := worker.destType
+		worker.mu.Unlock()
+		stats = append(stats, eventkit.DestinationStats{
+			Index: worker.id,
+			Type:  destType,
+			Stats: worker.stats.Snapshot(0),
+		})
+	}
+	return stats
 }
 
 var _ eventkit.Destination = &Parallel{}
+var _ eventkit.StatsReporter = &Parallel{}
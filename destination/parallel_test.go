@@ -1,6 +1,10 @@
 package destination
 
 import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -30,3 +34,102 @@ func TestParallel(t *testing.T) {
 	require.Len(t, m.events[1], 1)
 
 }
+
+func TestParallelRetriesWorkerCreation(t *testing.T) {
+	m := &mockDestination{}
+	var mu sync.Mutex
+	failures := 2
+	queue := NewParallel(func() (eventkit.Destination, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			return nil, errors.New("not yet")
+		}
+		return m, nil
+	}, 1)
+	go queue.Run(t.Context())
+
+	queue.Submit(&eventkit.Event{Name: "foobar"})
+	require.Eventually(t, func() bool {
+		return m.Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	stats := queue.Stats()
+	require.Equal(t, "not yet", stats.LastError)
+	require.Equal(t, int64(1), stats.Sent)
+
+	workers := queue.WorkerStats()
+	require.Len(t, workers, 1)
+	require.Equal(t, "*destination.mockDestination", workers[0].Type)
+	require.Equal(t, int64(1), workers[0].Sent)
+}
+
+func TestParallelDrainsOnShutdown(t *testing.T) {
+	m := &mockDestination{}
+	release := make(chan struct{})
+	blocking := &blockingDestination{mockDestination: m, release: release}
+	queue := NewParallel(func() (eventkit.Destination, error) {
+		return blocking, nil
+	}, 1)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		queue.Run(ctx)
+		close(done)
+	}()
+
+	// the first batch blocks the only worker, the second waits in the queue.
+	queue.Submit(&eventkit.Event{Name: "first"})
+	queue.Submit(&eventkit.Event{Name: "second"})
+	cancel()
+	close(release)
+	<-done
+
+	require.Equal(t, 2, m.Len())
+	require.False(t, blocking.submittedAfterStop.Load())
+}
+
+func TestParallelScales(t *testing.T) {
+	m := &mockDestination{}
+	release := make(chan struct{})
+	queue := NewParallel(func() (eventkit.Destination, error) {
+		return &blockingDestination{mockDestination: m, release: release}, nil
+	}, 1).WithWorkerRange(1, 3)
+	go queue.Run(t.Context())
+
+	for range 4 {
+		queue.Submit(&eventkit.Event{Name: "foobar"})
+	}
+	require.Eventually(t, func() bool {
+		return len(queue.WorkerStats()) == 3
+	}, 10*time.Second, 10*time.Millisecond)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		return m.Len() == 4 && len(queue.WorkerStats()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// blockingDestination blocks Submit until release is closed, and records if events were submitted after Run
+// returned.
+type blockingDestination struct {
+	*mockDestination
+	release            chan struct{}
+	stopped            atomic.Bool
+	submittedAfterStop atomic.Bool
+}
+
+func (b *blockingDestination) Submit(events ...*eventkit.Event) {
+	<-b.release
+	if b.stopped.Load() {
+		b.submittedAfterStop.Store(true)
+	}
+	b.mockDestination.Submit(events...)
+}
+
+func (b *blockingDestination) Run(ctx context.Context) {
+	<-ctx.Done()
+	b.stopped.Store(true)
+}
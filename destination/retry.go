@@ -0,0 +1,291 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/eventkit"
+	"storj.io/eventkit/pb"
+	"storj.io/eventkit/utils"
+	"storj.io/picobuf"
+)
+
+const (
+	defaultRetryMaxAttempts      = 5
+	defaultRetryBaseDelay        = time.Second
+	defaultRetryMaxDelay         = time.Minute
+	defaultRetryMaxInFlightBytes = 16 * 1024 * 1024
+)
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as an error which won't go away by retrying.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent returns true if err (or any error it wraps) was marked with Permanent.
+func IsPermanent(err error) bool {
+	var perr *permanentError
+	return errors.As(err, &perr)
+}
+
+// RetryOptions configures Retry. Zero values are replaced with the defaults.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of attempts to send a batch, including the first one.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. It's doubled for every following retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between retries.
+	MaxDelay time.Duration
+	// MaxInFlightBytes limits the size of the events waiting for a retry. Failed batches exceeding the limit are
+	// dropped.
+	MaxInFlightBytes int
+	// Retryable decides whether an error is worth retrying. By default everything is retried, except errors marked
+	// with Permanent and context cancellation.
+	Retryable func(error) bool
+}
+
+// Retry retries failed batches of a Sender with exponential backoff.
+//
+// Submit makes the first attempt to send the events on the caller's goroutine, so a slow target blocks the caller for
+// that attempt; put a BatchQueue in front of it to avoid that. Failed batches are kept in memory and retried from Run,
+// so the retries don't block the caller.
+type Retry struct {
+	target eventkit.Sender
+	opts   RetryOptions
+
+	wake chan struct{}
+
+	mu       sync.Mutex
+	rng      *rand.Rand
+	pending  []*retryBatch
+	inFlight int
+	queued   int
+
+	stats eventkit.StatsCounter
+}
+
+type retryBatch struct {
+	events   []*eventkit.Event
+	size     int
+	attempts int
+	next     time.Time
+}
+
+var _ eventkit.Destination = &Retry{}
+var _ eventkit.StatsReporter = &Retry{}
+
+// NewRetry creates a destination which retries the failed batches of target.
+func NewRetry(target eventkit.Sender, opts RetryOptions) *Retry {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = defaultRetryBaseDelay
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = defaultRetryMaxDelay
+	}
+	if opts.MaxInFlightBytes <= 0 {
+		opts.MaxInFlightBytes = defaultRetryMaxInFlightBytes
+	}
+	if opts.Retryable == nil {
+		opts.Retryable = defaultRetryable
+	}
+	return &Retry{
+		target: target,
+		opts:   opts,
+		wake:   make(chan struct{}, 1),
+		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func defaultRetryable(err error) bool {
+	return !IsPermanent(err) && !errors.Is(err, context.Canceled)
+}
+
+// Submit implements eventkit.Destination.
+func (r *Retry) Submit(events ...*eventkit.Event) {
+	r.attempt(&retryBatch{events: events})
+}
+
+// attempt sends the batch. It returns true when the batch failed and was scheduled for another attempt.
+func (r *Retry) attempt(b *retryBatch) (retrying bool) {
+	b.attempts++
+	err := r.target.Send(b.events...)
+	if err == nil {
+		r.stats.Sent(len(b.events))
+		return false
+	}
+
+	if !r.opts.Retryable(err) || b.attempts >= r.opts.MaxAttempts {
+		mon.Counter("retry_failed_events").Inc(int64(len(b.events)))
+		r.stats.Failed(len(b.events), err)
+		return false
+	}
+	r.stats.Error(err)
+
+	r.mu.Lock()
+	if b.attempts == 1 {
+		b.size = eventsSize(b.events)
+		if r.inFlight+b.size > r.opts.MaxInFlightBytes {
+			r.mu.Unlock()
+			mon.Counter("dropped_events").Inc(int64(len(b.events)))
+			r.stats.Dropped(len(b.events))
+			return false
+		}
+		r.inFlight += b.size
+		r.queued += len(b.events)
+	}
+	b.next = time.Now().Add(r.backoff(b.attempts))
+	r.pending = append(r.pending, b)
+	r.mu.Unlock()
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// backoff returns the jittered delay before the next attempt, after the given number of attempts. It must be
+// called with mu held.
+func (r *Retry) backoff(attempts int) time.Duration {
+	delay := r.opts.BaseDelay
+	for i := 1; i < attempts && delay < r.opts.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, r.opts.MaxDelay)
+	return utils.Jitter(r.rng, delay)
+}
+
+// release forgets about a batch which won't be retried anymore.
+func (r *Retry) release(b *retryBatch) {
+	r.mu.Lock()
+	r.inFlight -= b.size
+	r.queued -= len(b.events)
+	r.mu.Unlock()
+}
+
+// take removes and returns the batches which should be retried before deadline.
+func (r *Retry) take(deadline time.Time) (batches []*retryBatch) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	remaining := r.pending[:0]
+	for _, b := range r.pending {
+		if b.next.After(deadline) {
+			remaining = append(remaining, b)
+		} else {
+			batches = append(batches, b)
+		}
+	}
+	clear(r.pending[len(remaining):])
+	r.pending = remaining
+	return batches
+}
+
+// nextRetry returns the time until the next scheduled retry.
+func (r *Retry) nextRetry() (wait time.Duration, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var next time.Time
+	for _, b := range r.pending {
+		if next.IsZero() || b.next.Before(next) {
+			next = b.next
+		}
+	}
+	return time.Until(next), !next.IsZero()
+}
+
+// Run implements eventkit.Destination.
+//
+// Batches still waiting for a retry when ctx is canceled are dropped.
+func (r *Retry) Run(ctx context.Context) {
+	var background errgroup.Group
+	defer func() { _ = background.Wait() }()
+	background.Go(func() error {
+		r.target.Run(ctx)
+		return nil
+	})
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-r.wake:
+		case <-timer.C:
+		case <-ctx.Done():
+			r.mu.Lock()
+			batches := r.pending
+			r.pending = nil
+			r.mu.Unlock()
+			for _, b := range batches {
+				r.release(b)
+				mon.Counter("dropped_events").Inc(int64(len(b.events)))
+				r.stats.Dropped(len(b.events))
+			}
+			return
+		}
+
+		for _, b := range r.take(time.Now()) {
+			if !r.attempt(b) {
+				r.release(b)
+			}
+		}
+
+		timer.Stop()
+		if wait, ok := r.nextRetry(); ok {
+			timer.Reset(wait)
+		}
+	}
+}
+
+// Stats implements eventkit.StatsReporter. Queued is the number of events waiting for a retry.
+func (r *Retry) Stats() eventkit.Stats {
+	r.mu.Lock()
+	queued := r.queued
+	r.mu.Unlock()
+	return r.stats.Snapshot(queued)
+}
+
+// eventsSize estimates the memory used by the events with their encoded size.
+func eventsSize(events []*eventkit.Event) (size int) {
+	for _, e := range events {
+		size += eventSize(e)
+	}
+	return size
+}
+
+// eventSize returns the encoded size of the event in a packet.
+func eventSize(e *eventkit.Event) int {
+	data, err := picobuf.Marshal(&pb.Event{
+		Name:      e.Name,
+		Scope:     e.Scope,
+		Tags:      e.Tags,
+		SessionId: e.ID.Session,
+		Sequence:  e.ID.Sequence,
+		Severity:  e.Severity,
+	})
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit"
+)
+
+type failingSender struct {
+	mockDestination
+
+	mu       sync.Mutex
+	failures []error
+	attempts int
+}
+
+func (f *failingSender) Send(events ...*eventkit.Event) error {
+	f.mu.Lock()
+	f.attempts++
+	var err error
+	if len(f.failures) > 0 {
+		err, f.failures = f.failures[0], f.failures[1:]
+	}
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	f.Submit(events...)
+	return nil
+}
+
+func (f *failingSender) Attempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.attempts
+}
+
+func TestRetry(t *testing.T) {
+	transient := errors.New("transient")
+	target := &failingSender{failures: []error{transient, transient}}
+	r := NewRetry(target, RetryOptions{BaseDelay: time.Millisecond})
+	go r.Run(t.Context())
+
+	r.Submit(&eventkit.Event{Name: "a"}, &eventkit.Event{Name: "b"})
+	require.Eventually(t, func() bool {
+		return target.Len() == 1
+	}, 5*time.Second, time.Millisecond)
+	require.Equal(t, 3, target.Attempts())
+	require.Len(t, target.events[0], 2)
+
+	require.Eventually(t, func() bool {
+		return r.Stats().Queued == 0
+	}, 5*time.Second, time.Millisecond)
+	stats := r.Stats()
+	require.Equal(t, int64(2), stats.Sent)
+	require.Equal(t, "transient", stats.LastError)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	transient := errors.New("transient")
+	target := &failingSender{failures: []error{transient, transient, transient}}
+	r := NewRetry(target, RetryOptions{BaseDelay: time.Millisecond, MaxAttempts: 2})
+	go r.Run(t.Context())
+
+	r.Submit(&eventkit.Event{Name: "a"})
+	require.Eventually(t, func() bool {
+		return r.Stats().Failed == 1
+	}, 5*time.Second, time.Millisecond)
+	require.Equal(t, 2, target.Attempts())
+	require.Equal(t, int64(0), r.Stats().Queued)
+}
+
+func TestRetryPermanent(t *testing.T) {
+	target := &failingSender{failures: []error{Permanent(errors.New("bad request"))}}
+	r := NewRetry(target, RetryOptions{BaseDelay: time.Millisecond})
+
+	r.Submit(&eventkit.Event{Name: "a"})
+	require.Equal(t, 1, target.Attempts())
+	require.Equal(t, int64(1), r.Stats().Failed)
+	require.Equal(t, int64(0), r.Stats().Queued)
+}
+
+func TestRetryMaxInFlightBytes(t *testing.T) {
+	transient := errors.New("transient")
+	target := &failingSender{failures: []error{transient, transient}}
+	event := &eventkit.Event{Name: "a"}
+	r := NewRetry(target, RetryOptions{BaseDelay: time.Hour, MaxInFlightBytes: eventSize(event)})
+
+	r.Submit(event)
+	r.Submit(event)
+	stats := r.Stats()
+	require.Equal(t, int64(1), stats.Queued)
+	require.Equal(t, int64(1), stats.Dropped)
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"storj.io/eventkit"
+)
+
+// Sample forwards a random fraction of the events to the target.
+type Sample struct {
+	target eventkit.Destination
+	rate   float64
+
+	mu  sync.Mutex
+	rng *rand.Rand
+
+	stats eventkit.StatsCounter
+}
+
+var _ eventkit.Destination = &Sample{}
+var _ eventkit.StatsReporter = &Sample{}
+
+// NewSample creates a destination which sends each event to target with the probability rate (between 0 and 1), and
+// drops the rest.
+func NewSample(target eventkit.Destination, rate float64) *Sample {
+	return &Sample{
+		target: target,
+		rate:   rate,
+		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Submit implements eventkit.Destination.
+func (s *Sample) Submit(events ...*eventkit.Event) {
+	sampled := events[:0:0]
+	s.mu.Lock()
+	for _, e := range events {
+		if s.rng.Float64() < s.rate {
+			sampled = append(sampled, e)
+		}
+	}
+	s.mu.Unlock()
+
+	if dropped := len(events) - len(sampled); dropped > 0 {
+		mon.Counter("sampled_out_events").Inc(int64(dropped))
+		s.stats.Dropped(dropped)
+	}
+	if len(sampled) > 0 {
+		s.target.Submit(sampled...)
+		s.stats.Sent(len(sampled))
+	}
+}
+
+// Stats implements eventkit.StatsReporter. Dropped is the number of events which were not sampled.
+func (s *Sample) Stats() eventkit.Stats {
+	return s.stats.Snapshot(0)
+}
+
+// Run implements eventkit.Destination.
+func (s *Sample) Run(ctx context.Context) {
+	s.target.Run(ctx)
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit"
+)
+
+func TestSample(t *testing.T) {
+	m := &mockDestination{}
+	NewSample(m, 0).Submit(&eventkit.Event{Name: "a"}, &eventkit.Event{Name: "b"})
+	require.Equal(t, 0, m.Len())
+
+	NewSample(m, 1).Submit(&eventkit.Event{Name: "a"}, &eventkit.Event{Name: "b"})
+	require.Equal(t, 1, m.Len())
+	require.Len(t, m.events[0], 2)
+
+	s := NewSample(m, 0.5)
+	events := make([]*eventkit.Event, 1000)
+	for i := range events {
+		events[i] = &eventkit.Event{Name: "c"}
+	}
+	s.Submit(events...)
+	stats := s.Stats()
+	require.Equal(t, int64(1000), stats.Sent+stats.Dropped)
+	require.InDelta(t, 500, stats.Sent, 100)
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/spacemonkeygo/monkit/v3"
+
+	"storj.io/eventkit"
+)
+
+// StatsSource exposes the Stats of the destinations of a registry as monkit metrics. Register it with
+// (*monkit.Scope).Chain.
+type StatsSource struct {
+	registry *eventkit.Registry
+}
+
+var _ monkit.StatSource = &StatsSource{}
+
+// NewStatsSource creates a monkit.StatSource for the destinations of registry.
+func NewStatsSource(registry *eventkit.Registry) *StatsSource {
+	return &StatsSource{registry: registry}
+}
+
+// Stats implements monkit.StatSource.
+func (s *StatsSource) Stats(cb func(key monkit.SeriesKey, field string, val float64)) {
+	rs := s.registry.Stats()
+	report := func(key monkit.SeriesKey, stats eventkit.Stats) {
+		cb(key, "queued", float64(stats.Queued))
+		cb(key, "sent", float64(stats.Sent))
+		cb(key, "dropped", float64(stats.Dropped))
+		for _, priority := range eventkit.Priorities {
+			cb(key, "dropped_"+priority.String(), float64(stats.DroppedByPriority[priority.String()]))
+		}
+		cb(key, "failed", float64(stats.Failed))
+		cb(key, "bytes_sent", float64(stats.BytesSent))
+	}
+	report(monkit.NewSeriesKey("eventkit_destinations"), rs.Total)
+	for _, ds := range rs.Destinations {
+		report(monkit.NewSeriesKey("eventkit_destination").
+			WithTag("index", strconv.Itoa(ds.Index)).
+			WithTag("type", ds.Type), ds.Stats)
+	}
+}
+
+// StatsHandler returns an HTTP handler which responds with the Stats of the destinations of registry as JSON.
+func StatsHandler(registry *eventkit.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(registry.Stats())
+	})
+}
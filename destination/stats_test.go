@@ -0,0 +1,66 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit"
+)
+
+func TestRegistryStats(t *testing.T) {
+	m := &mockDestination{}
+	ctx := t.Context()
+	queue := NewBatchQueue(m, 1, 10, time.Hour)
+
+	registry := eventkit.NewRegistry()
+	registry.AddDestination(m)
+	registry.AddDestination(queue)
+	registry.AddDestination(NewFilter(m, eventkit.HasTag("keep")))
+
+	scope := registry.Scope("test")
+	scope.Event("a")
+	scope.Event("b")
+
+	rs := registry.Stats()
+	require.Len(t, rs.Destinations, 2)
+	require.Equal(t, 1, rs.Destinations[0].Index)
+	require.Equal(t, "*destination.BatchQueue", rs.Destinations[0].Type)
+	require.Equal(t, int64(1), rs.Destinations[0].Queued)
+	require.Equal(t, int64(1), rs.Destinations[0].Dropped)
+	require.Equal(t, int64(1), rs.Total.Dropped)
+
+	go queue.Run(ctx)
+	require.Eventually(t, func() bool {
+		return len(queue.submitQueues[eventkit.PriorityNormal.Class()]) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// both events wait in the batch until it's full.
+	scope.Event("c", eventkit.Bool("keep", true))
+	require.Eventually(t, func() bool {
+		rs := registry.Stats()
+		return rs.Total.Queued == 2 && rs.Total.Sent == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	values := map[string]float64{}
+	NewStatsSource(registry).Stats(func(key monkit.SeriesKey, field string, val float64) {
+		values[key.String()+" "+field] = val
+	})
+	require.Equal(t, float64(1), values["eventkit_destinations dropped"])
+	require.Equal(t, float64(1), values["eventkit_destinations dropped_normal"])
+	require.Equal(t, float64(0), values["eventkit_destinations dropped_critical"])
+	require.Equal(t, float64(1), values["eventkit_destination,index=2,type=*destination.Filter sent"])
+
+	rec := httptest.NewRecorder()
+	StatsHandler(registry).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	var decoded eventkit.RegistryStats
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+	require.Equal(t, registry.Stats().Total, decoded.Total)
+}
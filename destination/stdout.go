@@ -0,0 +1,358 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"storj.io/eventkit"
+	"storj.io/eventkit/pb"
+)
+
+const defaultLogWriterQueueSize = 1000
+
+// LogFormat is the line format of a LogWriter.
+type LogFormat int
+
+const (
+	// LogFormatJSON writes one JSON object per line, with the tags in the tags object.
+	LogFormatJSON LogFormat = iota
+	// LogFormatLogfmt writes key=value pairs, the tags following the time, scope and name.
+	LogFormatLogfmt
+	// LogFormatPretty writes human readable lines, the same way as eventkit-receiver.
+	LogFormatPretty
+)
+
+// ParseLogFormat parses the name of a LogFormat: json, logfmt or pretty.
+func ParseLogFormat(name string) (LogFormat, error) {
+	switch name {
+	case "json":
+		return LogFormatJSON, nil
+	case "logfmt":
+		return LogFormatLogfmt, nil
+	case "pretty":
+		return LogFormatPretty, nil
+	default:
+		return 0, fmt.Errorf("log format should be json, logfmt or pretty and not %s", name)
+	}
+}
+
+// colors of eventkit-receiver.
+const (
+	colorReset  = "\x1b[0m"
+	colorGreen  = "\x1b[38;2;1;162;82m"
+	colorYellow = "\x1b[38;2;253;237;2m"
+	colorRed    = "\x1b[38;2;219;45;32m"
+)
+
+// LogWriter writes the events as lines of text, for example to the standard output of a container.
+//
+// Tag values keep their types: numbers and booleans are written as they are, durations as Go durations (1.5s),
+// timestamps in RFC 3339 format and bytes in base64.
+//
+// Submit only queues the events, they are written from Run through a buffer, so a slow writer can't block the
+// caller. Events are dropped when the queue is full.
+type LogWriter struct {
+	w      *bufio.Writer
+	format LogFormat
+	color  bool
+	queue  chan *eventkit.Event
+	stats  eventkit.StatsCounter
+}
+
+var _ eventkit.Destination = &LogWriter{}
+var _ eventkit.StatsReporter = &LogWriter{}
+
+// NewLogWriter creates a destination writing the events to w in the given format.
+func NewLogWriter(w io.Writer, format LogFormat) *LogWriter {
+	return &LogWriter{
+		w:      bufio.NewWriter(w),
+		format: format,
+		queue:  make(chan *eventkit.Event, defaultLogWriterQueueSize),
+	}
+}
+
+// WithColor enables colors in the pretty format.
+//
+// It must be called before Run.
+func (l *LogWriter) WithColor(color bool) *LogWriter {
+	l.color = color
+	return l
+}
+
+// WithQueueSize sets the number of events which can wait to be written.
+//
+// It must be called before Run and Submit.
+func (l *LogWriter) WithQueueSize(size int) *LogWriter {
+	l.queue = make(chan *eventkit.Event, size)
+	return l
+}
+
+// Submit implements eventkit.Destination.
+func (l *LogWriter) Submit(events ...*eventkit.Event) {
+	for _, e := range events {
+		select {
+		case l.queue <- e:
+		default:
+			mon.Counter("dropped_events").Inc(1)
+			l.stats.Dropped(1)
+		}
+	}
+}
+
+// Run implements eventkit.Destination. The events still in the queue are written when ctx is canceled.
+func (l *LogWriter) Run(ctx context.Context) {
+	var line bytes.Buffer
+	write := func(e *eventkit.Event) {
+		line.Reset()
+		l.appendLine(&line, e)
+		if _, err := l.w.Write(line.Bytes()); err != nil {
+			l.stats.Failed(1, err)
+			return
+		}
+		l.stats.Sent(1)
+		l.stats.BytesSent(line.Len())
+	}
+	flush := func() {
+		if err := l.w.Flush(); err != nil {
+			l.stats.Error(err)
+		}
+	}
+
+	for {
+		select {
+		case e := <-l.queue:
+			write(e)
+			if len(l.queue) == 0 {
+				flush()
+			}
+		case <-ctx.Done():
+			for len(l.queue) > 0 {
+				write(<-l.queue)
+			}
+			flush()
+			return
+		}
+	}
+}
+
+// Stats implements eventkit.StatsReporter.
+func (l *LogWriter) Stats() eventkit.Stats {
+	return l.stats.Snapshot(len(l.queue))
+}
+
+func (l *LogWriter) appendLine(b *bytes.Buffer, e *eventkit.Event) {
+	switch l.format {
+	case LogFormatJSON:
+		appendJSONLine(b, e)
+	case LogFormatLogfmt:
+		appendLogfmtLine(b, e)
+	case LogFormatPretty:
+		l.appendPrettyLine(b, e)
+	}
+	b.WriteByte('\n')
+}
+
+func appendJSONLine(b *bytes.Buffer, e *eventkit.Event) {
+	b.WriteString(`{"time":`)
+	appendJSONString(b, e.Timestamp.Format(time.RFC3339Nano))
+	b.WriteString(`,"scope":[`)
+	for i, s := range e.Scope {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		appendJSONString(b, s)
+	}
+	b.WriteString(`],"name":`)
+	appendJSONString(b, e.Name)
+	if !e.ID.IsZero() {
+		b.WriteString(`,"id":`)
+		appendJSONString(b, e.ID.String())
+	}
+	if e.Severity != eventkit.SeverityUnspecified {
+		b.WriteString(`,"severity":`)
+		appendJSONString(b, e.Severity.String())
+	}
+	b.WriteString(`,"tags":{`)
+	for i, tag := range e.Tags {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		appendJSONString(b, tag.Key)
+		b.WriteByte(':')
+		appendJSONValue(b, tag)
+	}
+	b.WriteString("}}")
+}
+
+func appendJSONString(b *bytes.Buffer, s string) {
+	data, _ := json.Marshal(s)
+	b.Write(data)
+}
+
+func appendJSONValue(b *bytes.Buffer, tag eventkit.Tag) {
+	switch v := tag.Value.(type) {
+	case *pb.Tag_Int64:
+		b.WriteString(strconv.FormatInt(v.Int64, 10))
+	case *pb.Tag_Double:
+		if math.IsNaN(v.Double) || math.IsInf(v.Double, 0) {
+			// not representable in JSON.
+			appendJSONString(b, strconv.FormatFloat(v.Double, 'g', -1, 64))
+			return
+		}
+		b.WriteString(strconv.FormatFloat(v.Double, 'g', -1, 64))
+	case *pb.Tag_Bool:
+		b.WriteString(strconv.FormatBool(v.Bool))
+	case *pb.Tag_List:
+		b.WriteByte('[')
+		for i, value := range v.List.GetValues() {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			appendJSONValue(b, value)
+		}
+		b.WriteByte(']')
+	case *pb.Tag_Map:
+		b.WriteByte('{')
+		for i, nested := range v.Map.GetTags() {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			appendJSONString(b, nested.Key)
+			b.WriteByte(':')
+			appendJSONValue(b, nested)
+		}
+		b.WriteByte('}')
+	case *pb.Tag_Histogram:
+		appendJSONValue(b, &pb.Tag{Value: &pb.Tag_Map{Map: v.Histogram.AsMap()}})
+	case nil:
+		b.WriteString("null")
+	default:
+		appendJSONString(b, logValueString(tag))
+	}
+}
+
+func appendLogfmtLine(b *bytes.Buffer, e *eventkit.Event) {
+	b.WriteString("time=")
+	b.WriteString(e.Timestamp.Format(time.RFC3339Nano))
+	b.WriteString(" scope=")
+	appendLogfmtValue(b, strings.Join(e.Scope, "."))
+	b.WriteString(" name=")
+	appendLogfmtValue(b, e.Name)
+	if !e.ID.IsZero() {
+		b.WriteString(" id=")
+		b.WriteString(e.ID.String())
+	}
+	if e.Severity != eventkit.SeverityUnspecified {
+		b.WriteString(" severity=")
+		appendLogfmtValue(b, e.Severity.String())
+	}
+	for _, tag := range e.Tags {
+		b.WriteByte(' ')
+		appendLogfmtKey(b, tag.Key)
+		b.WriteByte('=')
+		appendLogfmtValue(b, logValueString(tag))
+	}
+}
+
+// appendLogfmtKey replaces the characters which are not allowed in logfmt keys.
+func appendLogfmtKey(b *bytes.Buffer, key string) {
+	if key == "" {
+		b.WriteByte('_')
+		return
+	}
+	for _, r := range key {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			b.WriteByte('_')
+			continue
+		}
+		b.WriteRune(r)
+	}
+}
+
+func appendLogfmtValue(b *bytes.Buffer, value string) {
+	if value == "" || strings.ContainsFunc(value, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError
+	}) {
+		b.WriteString(strconv.Quote(value))
+		return
+	}
+	b.WriteString(value)
+}
+
+func (l *LogWriter) appendPrettyLine(b *bytes.Buffer, e *eventkit.Event) {
+	l.appendColored(b, colorRed, e.Timestamp.Format(time.RFC3339))
+	b.WriteByte(' ')
+	if e.Severity != eventkit.SeverityUnspecified {
+		l.appendColored(b, severityColor(e.Severity), e.Severity.String())
+		b.WriteByte(' ')
+	}
+	l.appendColored(b, colorGreen, strings.Join(e.Scope, ".")+" "+e.Name)
+	for _, tag := range e.Tags {
+		b.WriteByte(' ')
+		b.WriteString(tag.Key)
+		b.WriteByte('=')
+		l.appendColored(b, colorYellow, logValueString(tag))
+	}
+}
+
+// severityColor returns the color of the severity in the pretty format, or an empty string for no color.
+func severityColor(severity eventkit.Severity) string {
+	switch {
+	case severity >= eventkit.SeverityError:
+		return colorRed
+	case severity >= eventkit.SeverityWarning:
+		return colorYellow
+	case severity >= eventkit.SeverityInfo:
+		return colorGreen
+	}
+	return ""
+}
+
+func (l *LogWriter) appendColored(b *bytes.Buffer, color, s string) {
+	if !l.color || color == "" {
+		b.WriteString(s)
+		return
+	}
+	b.WriteString(color)
+	b.WriteString(s)
+	b.WriteString(colorReset)
+}
+
+// logValueString formats the tag value for the text formats.
+func logValueString(tag eventkit.Tag) string {
+	switch v := tag.Value.(type) {
+	case *pb.Tag_Bytes:
+		return base64.StdEncoding.EncodeToString(v.Bytes)
+	case *pb.Tag_Timestamp:
+		return v.Timestamp.AsTime().Format(time.RFC3339Nano)
+	case *pb.Tag_List:
+		values := make([]string, 0, len(v.List.GetValues()))
+		for _, value := range v.List.GetValues() {
+			values = append(values, logValueString(value))
+		}
+		return "[" + strings.Join(values, ",") + "]"
+	case *pb.Tag_Map:
+		tags := make([]string, 0, len(v.Map.GetTags()))
+		for _, nested := range v.Map.GetTags() {
+			tags = append(tags, nested.Key+"="+logValueString(nested))
+		}
+		return "{" + strings.Join(tags, ",") + "}"
+	case *pb.Tag_Histogram:
+		return logValueString(&pb.Tag{Value: &pb.Tag_Map{Map: v.Histogram.AsMap()}})
+	default:
+		return tag.ValueString()
+	}
+}
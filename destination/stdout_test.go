@@ -0,0 +1,111 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package destination
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit"
+)
+
+func TestLogWriterFormats(t *testing.T) {
+	ts := time.Date(2026, 10, 18, 13, 5, 0, 0, time.UTC)
+	event := &eventkit.Event{
+		Name:      "upload",
+		Scope:     []string{"storj.io", "uplink"},
+		Timestamp: ts,
+		Tags: []eventkit.Tag{
+			eventkit.String("path", "a b"),
+			eventkit.Int64("size", 10),
+			eventkit.Float64("ratio", 0.5),
+			eventkit.Float64("nan", math.NaN()),
+			eventkit.Bool("ok", true),
+			eventkit.Bytes("id", []byte{1, 2, 3}),
+			eventkit.Duration("took", 1500*time.Millisecond),
+			eventkit.Timestamp("at", ts),
+			eventkit.Strings("labels", []string{"a", "b"}),
+			eventkit.Map("user", eventkit.Int64("id", 1), eventkit.Bools("flags", []bool{true})),
+		},
+	}
+
+	format := func(format LogFormat, color bool) string {
+		var b bytes.Buffer
+		NewLogWriter(nil, format).WithColor(color).appendLine(&b, event)
+		return b.String()
+	}
+
+	line := format(LogFormatJSON, false)
+	require.Equal(t, `{"time":"2026-10-18T13:05:00Z","scope":["storj.io","uplink"],"name":"upload","tags":{`+
+		`"path":"a b","size":10,"ratio":0.5,"nan":"NaN","ok":true,"id":"AQID","took":"1.5s","at":"2026-10-18T13:05:00Z",`+
+		`"labels":["a","b"],"user":{"id":1,"flags":[true]}}}`+"\n", line)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(line), &decoded))
+
+	require.Equal(t, `time=2026-10-18T13:05:00Z scope=storj.io.uplink name=upload `+
+		`path="a b" size=10 ratio=0.5 nan=NaN ok=true id=AQID took=1.5s at=2026-10-18T13:05:00Z `+
+		`labels=[a,b] user="{id=1,flags=[true]}"`+"\n", format(LogFormatLogfmt, false))
+
+	require.True(t, strings.HasPrefix(format(LogFormatPretty, false), "2026-10-18T13:05:00Z storj.io.uplink upload path=a b size=10"))
+	require.Contains(t, format(LogFormatPretty, true), colorGreen+"storj.io.uplink upload"+colorReset)
+
+	event.Tags = nil
+	event.Severity = eventkit.SeverityError
+	require.Equal(t, `{"time":"2026-10-18T13:05:00Z","scope":["storj.io","uplink"],"name":"upload","severity":"ERROR","tags":{}}`+"\n",
+		format(LogFormatJSON, false))
+	require.Equal(t, `time=2026-10-18T13:05:00Z scope=storj.io.uplink name=upload severity=ERROR`+"\n", format(LogFormatLogfmt, false))
+	require.Contains(t, format(LogFormatPretty, true), colorRed+"ERROR"+colorReset)
+}
+
+func TestLogWriterRun(t *testing.T) {
+	out := &lockedBuffer{}
+	l := NewLogWriter(out, LogFormatLogfmt).WithQueueSize(2)
+
+	// the queue is full until Run starts.
+	l.Submit(&eventkit.Event{Name: "a"}, &eventkit.Event{Name: "b"}, &eventkit.Event{Name: "c"})
+	require.Equal(t, int64(1), l.Stats().Dropped)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		l.Run(ctx)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		return strings.Count(out.String(), "\n") == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	l.Submit(&eventkit.Event{Name: "d"})
+	cancel()
+	<-done
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Contains(t, lines[2], "name=d")
+	require.Equal(t, int64(3), l.Stats().Sent)
+}
+
+type lockedBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (l *lockedBuffer) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.b.Write(p)
+}
+
+func (l *lockedBuffer) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.b.String()
+}
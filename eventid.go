@@ -0,0 +1,43 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+
+	"storj.io/eventkit/pb"
+)
+
+// EventID identifies an event uniquely, so the duplicates created by
+// retries or redundant collectors can be removed. The zero value means that
+// the event has no ID.
+type EventID struct {
+	// Session is a random identifier of the source of the events.
+	Session uint64
+	// Sequence is the number of the event in the session.
+	Sequence uint64
+}
+
+// IsZero returns whether the ID is unset.
+func (id EventID) IsZero() bool { return id == EventID{} }
+
+// String returns the ID in the form used as the deduplication key.
+func (id EventID) String() string { return pb.EventID(id.Session, id.Sequence) }
+
+// UniqueIDs assigns a unique ID to every event which doesn't have one yet.
+// The IDs consist of a random session, which is different for every call
+// of UniqueIDs, and a sequence number.
+func UniqueIDs() Middleware {
+	session := rand.Uint64() | 1
+	var sequence atomic.Uint64
+	return func(e *Event) *Event {
+		if !e.ID.IsZero() {
+			return e
+		}
+		c := *e
+		c.ID = EventID{Session: session, Sequence: sequence.Add(1)}
+		return &c
+	}
+}
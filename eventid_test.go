@@ -0,0 +1,46 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"testing"
+	"time"
+
+	"storj.io/eventkit/transport"
+)
+
+func TestUniqueIDs(t *testing.T) {
+	r := NewRegistry()
+	dest := &recordingDestination{}
+	r.AddDestination(dest)
+	r.Use(UniqueIDs())
+
+	fixed := EventID{Session: 7, Sequence: 9}
+	r.Scope("test").Event("a")
+	r.Scope("test").Event("b")
+	r.Submit(&Event{Name: "c", ID: fixed})
+
+	first, second := dest.events[0].ID, dest.events[1].ID
+	requireEqual(t, first.IsZero(), false)
+	requireEqual(t, first.Session, second.Session)
+	requireEqual(t, first.Sequence+1, second.Sequence)
+	requireEqual(t, dest.events[2].ID, fixed)
+	requireEqual(t, fixed.String(), "0000000000000007-9")
+	requireEqual(t, EventID{}.String(), "")
+
+	// the IDs are sent to the collector.
+	client := NewUDPClient("application", "v1.0.0", "instance", "127.0.0.1:0")
+	packet := client.newShard(client.QueueDepth).newOutgoingPacket()
+	for _, e := range dest.events {
+		e.Timestamp = time.Now()
+		packet.addEvent(e)
+	}
+	datagrams, _ := packet.finalize()
+	parsed, err := transport.ParsePacket(datagrams[0])
+	requireNoError(t, err)
+	requireEqual(t, parsed.Events[0].SessionId, first.Session)
+	requireEqual(t, parsed.Events[0].Sequence, first.Sequence)
+	requireEqual(t, parsed.Events[2].SessionId, uint64(7))
+	requireEqual(t, parsed.Events[2].Sequence, uint64(9))
+}
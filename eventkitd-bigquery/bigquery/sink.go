@@ -50,7 +50,9 @@ func (b *BigQuerySink) Receive(ctx context.Context, unparsed *listener.Packet, p
 			ReceivedAt: unparsed.ReceivedAt,
 			Timestamp:  eventTime,
 			Correction: correction,
-			Tags:       event.Tags,
+			ID:         pb.EventID(event.SessionId, event.Sequence),
+			Severity:   event.Severity,
+			Tags:       pb.MergeTags(event.Tags, packet.Tags),
 		})
 	}
 
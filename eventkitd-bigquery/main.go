@@ -295,6 +295,7 @@ var _ bigquery.ValueSaver = &Record{}
 
 type Config struct {
 	Address        *string
+	TCPAddress     *string
 	MetricsAddress *string
 	PCAPInterface  *string
 	Workers        *int
@@ -313,6 +314,7 @@ type Config struct {
 func main() {
 	cfg := Config{}
 	cfg.Address = flag.String("addr", ":9002", "udp address to listen on")
+	cfg.TCPAddress = flag.String("tcp-addr", "", "if set, also receive packets over tcp on this address")
 	cfg.MetricsAddress = flag.String("metrics-addr", "", "HTTP address to listen on with /metrics endpoint")
 	cfg.PCAPInterface = flag.String("pcap-iface", "", "if set, use pcap for udp packets on this interface. must be on linux")
 	cfg.Workers = flag.Int("workers", runtime.NumCPU(), "number of workers")
@@ -331,7 +333,7 @@ func main() {
 		panic(err)
 	}
 
-	listener.ProcessPackages(*cfg.Workers, *cfg.PCAPInterface, *cfg.Address, *cfg.MetricsAddress, func(ctx context.Context, unparsed *listener.Packet, packet *pb.Packet) error {
+	listener.ProcessPackages(*cfg.Workers, *cfg.PCAPInterface, *cfg.Address, *cfg.TCPAddress, *cfg.MetricsAddress, func(ctx context.Context, unparsed *listener.Packet, packet *pb.Packet) error {
 		if *cfg.Filter != "" && *cfg.Filter != packet.Application {
 			return nil
 		}
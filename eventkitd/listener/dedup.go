@@ -0,0 +1,76 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package listener
+
+import (
+	"sync"
+	"time"
+)
+
+// Deduplicator remembers the IDs of the received events, to drop the
+// duplicates which arrive within a time window, e.g. because of retries or
+// redundant collectors. It's safe for concurrent use.
+type Deduplicator struct {
+	window time.Duration
+
+	mu                sync.Mutex
+	current, previous map[eventID]struct{}
+	rotated           time.Time
+}
+
+type eventID struct {
+	session, sequence uint64
+}
+
+// NewDeduplicator creates a Deduplicator, which remembers the IDs for at
+// least window, and at most twice as long.
+func NewDeduplicator(window time.Duration) *Deduplicator {
+	return &Deduplicator{
+		window:  window,
+		current: map[eventID]struct{}{},
+	}
+}
+
+// Duplicate returns true when the event with the ID has already been seen,
+// otherwise it remembers the ID. Events without an ID are never duplicates.
+// When the event can't be processed, its ID should be forgotten with
+// Forget, so a retransmitted copy isn't dropped.
+func (d *Deduplicator) Duplicate(session, sequence uint64, now time.Time) bool {
+	if session == 0 && sequence == 0 {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if elapsed := now.Sub(d.rotated); elapsed >= d.window {
+		d.previous = d.current
+		if elapsed >= 2*d.window {
+			d.previous = nil
+		}
+		d.current = map[eventID]struct{}{}
+		d.rotated = now
+	}
+
+	id := eventID{session: session, sequence: sequence}
+	if _, found := d.current[id]; found {
+		return true
+	}
+	if _, found := d.previous[id]; found {
+		return true
+	}
+	d.current[id] = struct{}{}
+	return false
+}
+
+// Forget removes the ID of an event, which was remembered by Duplicate but
+// couldn't be processed.
+func (d *Deduplicator) Forget(session, sequence uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	id := eventID{session: session, sequence: sequence}
+	delete(d.current, id)
+	delete(d.previous, id)
+}
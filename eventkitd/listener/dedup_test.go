@@ -0,0 +1,39 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package listener
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeduplicator(t *testing.T) {
+	now := time.Now()
+	d := NewDeduplicator(time.Minute)
+
+	check := func(session, sequence uint64, at time.Duration, expected bool) {
+		t.Helper()
+		if d.Duplicate(session, sequence, now.Add(at)) != expected {
+			t.Fatalf("%d/%d at %v should be duplicate=%v", session, sequence, at, expected)
+		}
+	}
+
+	check(0, 0, 0, false)
+	check(0, 0, 0, false)
+
+	check(1, 1, 0, false)
+	check(1, 2, 0, false)
+	check(2, 1, 0, false)
+	check(1, 1, time.Second, true)
+
+	// remembered for at least the window.
+	check(1, 2, 90*time.Second, true)
+	// but forgotten after twice the window.
+	check(2, 1, 4*time.Minute, false)
+
+	// or when the event couldn't be processed.
+	d.Forget(2, 1)
+	check(2, 1, 4*time.Minute, false)
+	check(2, 1, 4*time.Minute, true)
+}
@@ -2,12 +2,14 @@ package listener
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"runtime"
+	"sync"
 	"syscall"
 	"time"
 
@@ -25,14 +27,28 @@ var mon = monkit.Package()
 
 type Handler func(ctx context.Context, unparsed *Packet, packet *pb.Packet) error
 
-func ProcessPackages(workers int, PCAPIface string, address string, metricsAddress string, handler Handler) {
+func ProcessPackages(workers int, PCAPIface string, address string, tcpAddress string, metricsAddress string, handler Handler) {
 	log, _ := zap.NewProduction()
 
 	ctx, done := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer done()
 
 	queue := make(chan *Packet, workers)
+	closeQueue := sync.OnceFunc(func() { close(queue) })
+	reassembler := transport.NewReassembler(0)
 	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case now := <-ticker.C:
+				reassembler.Expire(now)
+			}
+		}
+	})
 	for range workers {
 		eg.Go(func() error {
 			for {
@@ -45,6 +61,15 @@ func ProcessPackages(workers int, PCAPIface string, address string, metricsAddre
 						fmt.Println(err)
 						continue
 					}
+					packet, err = reassembler.Add(unparsed.Source.String(), packet, unparsed.ReceivedAt)
+					if err != nil {
+						fmt.Println(err)
+						continue
+					}
+					if packet == nil {
+						// waiting for the other fragments of the event.
+						continue
+					}
 					mon.IntVal("received_events").Observe(int64(len(packet.Events)))
 					err = handler(ctx, unparsed, packet)
 					if err != nil {
@@ -110,7 +135,7 @@ func ProcessPackages(workers int, PCAPIface string, address string, metricsAddre
 				for {
 					packet, err := src.NextPacket()
 					if err != nil {
-						close(queue)
+						closeQueue()
 						_ = eg.Wait()
 						panic(err)
 					}
@@ -149,7 +174,7 @@ func ProcessPackages(workers int, PCAPIface string, address string, metricsAddre
 			for {
 				payload, source, err := listener.Next()
 				if err != nil {
-					close(queue)
+					closeQueue()
 					_ = eg.Wait()
 					panic(err)
 				}
@@ -162,6 +187,35 @@ func ProcessPackages(workers int, PCAPIface string, address string, metricsAddre
 			}
 		})
 	}
+	if tcpAddress != "" {
+		tcp, err := transport.ListenTCP(tcpAddress)
+		if err != nil {
+			panic(err)
+		}
+		defer func() { _ = tcp.Close() }()
+
+		noWait := &errgroup.Group{}
+		noWait.Go(func() error {
+			for {
+				payload, source, err := tcp.Next()
+				if errors.Is(err, net.ErrClosed) {
+					return nil
+				}
+				if err != nil {
+					closeQueue()
+					_ = eg.Wait()
+					panic(err)
+				}
+
+				queue <- &Packet{
+					Payload: payload,
+					// the handlers know the sources as UDP addresses.
+					Source:     &net.UDPAddr{IP: source.IP, Port: source.Port, Zone: source.Zone},
+					ReceivedAt: time.Now(),
+				}
+			}
+		})
+	}
 	<-ctx.Done()
 	log.Info("shutting down")
 
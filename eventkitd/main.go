@@ -7,25 +7,38 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/spacemonkeygo/monkit/v3"
+
 	"storj.io/eventkit/eventkitd/listener"
 	"storj.io/eventkit/eventkitd/private/path"
 	"storj.io/eventkit/pb"
 )
 
+var mon = monkit.Package()
+
 var (
 	flagAddr      = flag.String("addr", ":9002", "udp address to listen on")
+	flagTCPAddr   = flag.String("tcp-addr", "", "if set, also receive packets over tcp on this address")
 	flagWorkers   = flag.Int("workers", runtime.NumCPU(), "number of workers")
 	flagPath      = flag.String("base-path", "./data/", "path to write to")
 	flagPCAPIface = flag.String("pcap-iface", "", "if set, use pcap for udp packets on this interface. must be on linux")
+	flagDedup     = flag.Duration("dedup-window", 0, "if set, drop the events with an id which was already received within this window")
 )
 
 func eventToRecord(packet *pb.Packet, event *pb.Event, source *net.UDPAddr, received time.Time) (rv *pb.Record, recordPath string) {
 	var record pb.Record
+	record.Version = pb.RecordVersion
+	record.Name = event.Name
+	record.Scope = event.Scope
+	record.Received = pb.AsTimestamp(received)
 	record.Application = packet.Application
 	record.ApplicationVersion = packet.ApplicationVersion
 	record.Instance = packet.Instance
-	record.Tags = event.Tags
+	record.Tags = pb.MergeTags(event.Tags, packet.Tags)
 	record.SourceAddr = source.String()
+	record.SessionId = event.SessionId
+	record.Sequence = event.Sequence
+	record.Severity = event.Severity
 
 	// the event timestamp and the packet send timestamp are offsets from the packet's
 	// start_timestamp, which is determined from the sender's system clock. the
@@ -59,11 +72,24 @@ func main() {
 		}
 	}()
 
-	listener.ProcessPackages(*flagWorkers, *flagPCAPIface, *flagAddr, "", func(ctx context.Context, unparsed *listener.Packet, packet *pb.Packet) error {
+	var dedup *listener.Deduplicator
+	if *flagDedup > 0 {
+		dedup = listener.NewDeduplicator(*flagDedup)
+	}
+
+	listener.ProcessPackages(*flagWorkers, *flagPCAPIface, *flagAddr, *flagTCPAddr, "", func(ctx context.Context, unparsed *listener.Packet, packet *pb.Packet) error {
 		for _, event := range packet.Events {
+			if dedup != nil && dedup.Duplicate(event.SessionId, event.Sequence, unparsed.ReceivedAt) {
+				mon.Counter("duplicate_events").Inc(1)
+				continue
+			}
 			record, eventPath := eventToRecord(packet, event, unparsed.Source, unparsed.ReceivedAt)
 			err := writer.Append(eventPath, record)
 			if err != nil {
+				if dedup != nil {
+					// the retransmitted copy should still be written.
+					dedup.Forget(event.SessionId, event.Sequence)
+				}
 				return err
 			}
 		}
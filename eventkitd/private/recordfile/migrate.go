@@ -0,0 +1,106 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package recordfile
+
+import (
+	"compress/zlib"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"storj.io/eventkit/eventkitd/private/protostream"
+	"storj.io/eventkit/eventkitd/private/resumablecompressed"
+)
+
+// TempPrefix is the prefix of the files written by Migrate before they
+// replace the original. These are never record files, as the escaped names
+// don't contain dots.
+const TempPrefix = ".migrate-"
+
+// Migrate rewrites the record file at fpath, so all of its records are of
+// the current version. The file is replaced only when it had older records,
+// and only after all of them are read and written successfully. It returns
+// the number of the upgraded records.
+//
+// The file must not be written concurrently, so the files of the current
+// hour, which eventkitd may still append to, shouldn't be migrated.
+func Migrate(fpath string, dryRun bool) (upgraded int, err error) {
+	r, err := Open(fpath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errors.Join(err, r.Close()) }()
+
+	tempPath := filepath.Join(filepath.Dir(fpath), TempPrefix+filepath.Base(fpath))
+	var temp *os.File
+	var w *resumablecompressed.Writer
+	if !dryRun {
+		temp, err = os.OpenFile(tempPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err != nil {
+			return 0, err
+		}
+		w, err = resumablecompressed.NewWriter(syncCloser{temp}, zlib.DefaultCompression)
+		if err != nil {
+			_ = temp.Close()
+			_ = os.Remove(tempPath)
+			return 0, err
+		}
+	}
+	cleanup := func() {
+		if w != nil {
+			_ = w.Close()
+			_ = os.Remove(tempPath)
+		}
+	}
+
+	var stream *protostream.Writer
+	if w != nil {
+		stream = protostream.NewWriter(w)
+	}
+	for {
+		record, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			cleanup()
+			return 0, err
+		}
+		if stream != nil {
+			if err := stream.Marshal(record); err != nil {
+				cleanup()
+				return 0, err
+			}
+		}
+	}
+
+	upgraded = r.Upgraded()
+	if w == nil {
+		return upgraded, nil
+	}
+	if upgraded == 0 {
+		cleanup()
+		return 0, nil
+	}
+	if err := w.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return 0, err
+	}
+	if err := os.Rename(tempPath, fpath); err != nil {
+		_ = os.Remove(tempPath)
+		return 0, err
+	}
+	return upgraded, nil
+}
+
+// syncCloser flushes the file to the disk before closing it, so the
+// original file is replaced only with complete data.
+type syncCloser struct {
+	*os.File
+}
+
+func (f syncCloser) Close() error {
+	return errors.Join(f.Sync(), f.File.Close())
+}
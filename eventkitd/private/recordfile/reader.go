@@ -0,0 +1,98 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package recordfile reads the record files written by eventkitd.
+package recordfile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"storj.io/eventkit/eventkitd/private/path"
+	"storj.io/eventkit/eventkitd/private/protostream"
+	"storj.io/eventkit/eventkitd/private/resumablecompressed"
+	"storj.io/eventkit/pb"
+)
+
+// Reader reads the records of a file. The records of every version are
+// returned as self-describing records: the name and scope of the older
+// records are taken from the path of the file.
+type Reader struct {
+	stream *protostream.Reader
+	closer io.Closer
+
+	name     string
+	scope    []string
+	pathErr  error
+	upgraded int
+}
+
+// Open opens the record file at the path. The temporary files left by an
+// interrupted Migrate are rejected, so their records are not read twice.
+func Open(fpath string) (*Reader, error) {
+	if strings.HasPrefix(filepath.Base(fpath), TempPrefix) {
+		return nil, fmt.Errorf("%s is a temporary file of Migrate", fpath)
+	}
+	fh, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	r := NewReader(fh, fpath)
+	r.closer = fh
+	return r, nil
+}
+
+// NewReader creates a Reader for the compressed records of the file at
+// fpath. The path is used only for the name and scope of the records
+// written before RecordVersion, so it's an error only when there are such
+// records.
+func NewReader(base io.Reader, fpath string) *Reader {
+	r := &Reader{
+		stream: protostream.NewReader(resumablecompressed.NewReader(base)),
+	}
+	r.name, r.scope, r.pathErr = path.Parse(fpath)
+	return r
+}
+
+// Next returns the next record, or io.EOF after the last one.
+func (r *Reader) Next() (*pb.Record, error) {
+	var record pb.Record
+	if err := r.stream.Unmarshal(&record); err != nil {
+		return nil, err
+	}
+	if record.Version < pb.RecordVersion {
+		if r.pathErr != nil {
+			return nil, r.pathErr
+		}
+		Upgrade(&record, r.name, r.scope)
+		r.upgraded++
+	}
+	return &record, nil
+}
+
+// Upgraded returns the number of the records returned so far, which were
+// written before RecordVersion.
+func (r *Reader) Upgraded() int { return r.upgraded }
+
+// Close closes the file, when the Reader was created by Open.
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// Upgrade converts a record written before RecordVersion to the current
+// version, with the name and scope of its path. The received timestamp of
+// such records is unknown, and it's left empty.
+func Upgrade(record *pb.Record, name string, scope []string) {
+	if record.Version >= pb.RecordVersion {
+		return
+	}
+	record.Version = pb.RecordVersion
+	record.Name = name
+	record.Scope = scope
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package recordfile
+
+import (
+	"compress/zlib"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit/eventkitd/private/path"
+	"storj.io/eventkit/eventkitd/private/protostream"
+	"storj.io/eventkit/eventkitd/private/resumablecompressed"
+	"storj.io/eventkit/pb"
+)
+
+func writeRecords(t *testing.T, fpath string, records ...*pb.Record) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(fpath), 0755))
+	fh, err := os.OpenFile(fpath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	w, err := resumablecompressed.NewWriter(fh, zlib.DefaultCompression)
+	require.NoError(t, err)
+	stream := protostream.NewWriter(w)
+	for _, record := range records {
+		require.NoError(t, stream.Marshal(record))
+	}
+	require.NoError(t, w.Close())
+}
+
+func readRecords(t *testing.T, fpath string) (records []*pb.Record, upgraded int) {
+	r, err := Open(fpath)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, r.Close()) }()
+	for {
+		record, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return records, r.Upgraded()
+		}
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+}
+
+func TestReader(t *testing.T) {
+	base := t.TempDir() + string(filepath.Separator)
+	fpath := path.Compute(base, time.Now(), []string{"storj.io", "uplink"}, "upload")
+	received := pb.AsTimestamp(time.Now())
+	writeRecords(t, fpath,
+		&pb.Record{Application: "old"},
+		&pb.Record{Application: "new", Version: pb.RecordVersion, Name: "copied", Scope: []string{"other"}, Received: received},
+	)
+
+	records, upgraded := readRecords(t, fpath)
+	require.Equal(t, 1, upgraded)
+	require.Len(t, records, 2)
+	require.Equal(t, "old", records[0].Application)
+	require.Equal(t, uint32(pb.RecordVersion), records[0].Version)
+	require.Equal(t, "upload", records[0].Name)
+	require.Equal(t, []string{"storj.io", "uplink"}, records[0].Scope)
+	require.Nil(t, records[0].Received)
+	// the self-describing records keep their identity, even in another file.
+	require.Equal(t, "copied", records[1].Name)
+	require.Equal(t, []string{"other"}, records[1].Scope)
+	require.Equal(t, received.AsTime(), records[1].Received.AsTime())
+
+	// older records need a record file path.
+	other := filepath.Join(t.TempDir(), "not.a.record")
+	writeRecords(t, other, &pb.Record{Version: pb.RecordVersion, Name: "new"})
+	records, _ = readRecords(t, other)
+	require.Equal(t, "new", records[0].Name)
+	writeRecords(t, other, &pb.Record{})
+	r, err := Open(other)
+	require.NoError(t, err)
+	_, err = r.Next()
+	require.NoError(t, err)
+	_, err = r.Next()
+	require.Error(t, err)
+	require.NoError(t, r.Close())
+}
+
+func TestMigrate(t *testing.T) {
+	base := t.TempDir() + string(filepath.Separator)
+	fpath := path.Compute(base, time.Now(), []string{"storj.io"}, "upload")
+	writeRecords(t, fpath, &pb.Record{Application: "a"}, &pb.Record{Application: "b"})
+	writeRecords(t, fpath, &pb.Record{Application: "c", Version: pb.RecordVersion, Name: "upload", Scope: []string{"storj.io"}})
+
+	upgraded, err := Migrate(fpath, true)
+	require.NoError(t, err)
+	require.Equal(t, 2, upgraded)
+	_, upgraded = readRecords(t, fpath)
+	require.Equal(t, 2, upgraded, "dry run doesn't modify the file")
+
+	upgraded, err = Migrate(fpath, false)
+	require.NoError(t, err)
+	require.Equal(t, 2, upgraded)
+
+	records, upgraded := readRecords(t, fpath)
+	require.Equal(t, 0, upgraded)
+	require.Len(t, records, 3)
+	for i, application := range []string{"a", "b", "c"} {
+		require.Equal(t, application, records[i].Application)
+		require.Equal(t, "upload", records[i].Name)
+		require.Equal(t, []string{"storj.io"}, records[i].Scope)
+	}
+
+	upgraded, err = Migrate(fpath, false)
+	require.NoError(t, err)
+	require.Equal(t, 0, upgraded)
+
+	entries, err := os.ReadDir(filepath.Dir(fpath))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "no temporary files are left")
+
+	// the temporary file of an interrupted migration isn't read.
+	tempPath := filepath.Join(filepath.Dir(fpath), TempPrefix+filepath.Base(fpath))
+	writeRecords(t, tempPath, &pb.Record{Application: "a", Version: pb.RecordVersion})
+	_, err = Open(tempPath)
+	require.Error(t, err)
+	require.NoError(t, os.Remove(tempPath))
+
+	// broken files are left as they are.
+	require.NoError(t, os.WriteFile(fpath, []byte("broken"), 0644))
+	_, err = Migrate(fpath, false)
+	require.Error(t, err)
+	data, err := os.ReadFile(fpath)
+	require.NoError(t, err)
+	require.Equal(t, "broken", string(data))
+	entries, err = os.ReadDir(filepath.Dir(fpath))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
@@ -14,8 +14,7 @@ import (
 	"time"
 
 	"storj.io/eventkit/eventkitd/private/path"
-	"storj.io/eventkit/eventkitd/private/protostream"
-	"storj.io/eventkit/eventkitd/private/resumablecompressed"
+	"storj.io/eventkit/eventkitd/private/recordfile"
 	"storj.io/eventkit/pb"
 )
 
@@ -39,19 +38,27 @@ func tagValueToString(tag *pb.Tag) string {
 		return time.Duration(t.DurationNs).String()
 	case *pb.Tag_Timestamp:
 		return t.Timestamp.AsTime().String()
+	case *pb.Tag_List, *pb.Tag_Map, *pb.Tag_Histogram:
+		return tag.ValueString()
 	}
 }
 
-func recordToRow(name string, scope []string, record *pb.Record) rowMap {
+func recordToRow(record *pb.Record) rowMap {
 	rv := make(rowMap)
-	rv["name"] = name
-	rv["scope"] = path.EncodeScope(scope)
+	rv["name"] = record.Name
+	rv["scope"] = path.EncodeScope(record.Scope)
 	rv["application"] = record.Application
 	rv["version"] = record.ApplicationVersion
 	rv["instance"] = record.Instance
 	rv["source_addr"] = record.SourceAddr
 	rv["timestamp"] = record.Timestamp.AsTime().String()
 	rv["timestamp_correction"] = time.Duration(record.TimestampCorrectionNs).String()
+	if record.Received != nil {
+		rv["received"] = record.Received.AsTime().String()
+	}
+	if record.Severity != pb.Severity_UNSPECIFIED {
+		rv["severity"] = record.Severity.String()
+	}
 	for _, tag := range record.Tags {
 		rv[fmt.Sprintf("tag:%s", tag.Key)] = tagValueToString(tag)
 	}
@@ -65,26 +72,20 @@ func main() {
 	for _, dpath := range flag.Args() {
 		err := filepath.WalkDir(dpath, func(fpath string, d fs.DirEntry, err error) error {
 			if d.Type().IsRegular() {
-				name, scope, err := path.Parse(fpath)
-				if err != nil {
-					return nil
-				}
-				fh, err := os.Open(fpath)
+				r, err := recordfile.Open(fpath)
 				if err != nil {
 					return nil
 				}
-				defer func() { _ = fh.Close() }()
-				r := protostream.NewReader(resumablecompressed.NewReader(fh))
+				defer func() { _ = r.Close() }()
 				for {
-					var record pb.Record
-					err := r.Unmarshal(&record)
+					record, err := r.Next()
 					if err != nil {
 						if errors.Is(err, io.EOF) {
 							break
 						}
 						return nil
 					}
-					row := recordToRow(name, scope, &record)
+					row := recordToRow(record)
 					for key := range row {
 						header[key] = ""
 					}
@@ -9,38 +9,22 @@ import (
 	"os"
 	"path/filepath"
 
-	"storj.io/eventkit/eventkitd/private/path"
-	"storj.io/eventkit/eventkitd/private/protostream"
-	"storj.io/eventkit/eventkitd/private/resumablecompressed"
-	"storj.io/eventkit/pb"
+	"storj.io/eventkit/eventkitd/private/recordfile"
 )
 
-type jsonRecord struct {
-	*pb.Record
-
-	Name  string   `json:"name"`
-	Scope []string `json:"scope"`
-}
-
 func main() {
 	flag.Parse()
 	enc := json.NewEncoder(os.Stdout)
 	for _, dpath := range flag.Args() {
 		err := filepath.WalkDir(dpath, func(fpath string, d fs.DirEntry, err error) error {
 			if d.Type().IsRegular() {
-				name, scope, err := path.Parse(fpath)
-				if err != nil {
-					return nil
-				}
-				fh, err := os.Open(fpath)
+				r, err := recordfile.Open(fpath)
 				if err != nil {
 					return nil
 				}
-				defer func() { _ = fh.Close() }()
-				r := protostream.NewReader(resumablecompressed.NewReader(fh))
+				defer func() { _ = r.Close() }()
 				for {
-					var record pb.Record
-					err := r.Unmarshal(&record)
+					record, err := r.Next()
 					if err != nil {
 						if errors.Is(err, io.EOF) {
 							break
@@ -48,11 +32,7 @@ func main() {
 						return nil
 					}
 
-					err = enc.Encode(jsonRecord{
-						Record: &record,
-						Name:   name,
-						Scope:  scope,
-					})
+					err = enc.Encode(record)
 					if err != nil {
 						return err
 					}
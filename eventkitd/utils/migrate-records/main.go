@@ -0,0 +1,73 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// migrate-records rewrites the record files of eventkitd, so all the records
+// include their name, scope and received timestamp. The arguments are the
+// directories to migrate, like the hour directories (<base>/2006-01/02-15)
+// or the whole base directory.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"storj.io/eventkit/eventkitd/private/recordfile"
+)
+
+var (
+	flagDryRun = flag.Bool("dry-run", false, "only count the records which would be migrated")
+	flagMinAge = flag.Duration("min-age", 2*time.Hour, "skip the files modified more recently, which eventkitd may still append to")
+)
+
+func main() {
+	flag.Parse()
+	var files, records, failed int
+	for _, dpath := range flag.Args() {
+		err := filepath.WalkDir(dpath, func(fpath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() || strings.HasPrefix(d.Name(), ".") {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			if time.Since(info.ModTime()) < *flagMinAge {
+				fmt.Fprintf(os.Stderr, "skipping recently modified %s\n", fpath)
+				return nil
+			}
+
+			upgraded, err := recordfile.Migrate(fpath, *flagDryRun)
+			if err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "failed to migrate %s: %v\n", fpath, err)
+				return nil
+			}
+			if upgraded > 0 {
+				files++
+				records += upgraded
+			}
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	verb := "migrated"
+	if *flagDryRun {
+		verb = "would migrate"
+	}
+	fmt.Printf("%s %d records in %d files\n", verb, records, files)
+	if failed > 0 {
+		fmt.Printf("%d files failed\n", failed)
+		os.Exit(1)
+	}
+}
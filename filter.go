@@ -0,0 +1,143 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Filter decides whether an event should be sent to a destination.
+type Filter func(*Event) bool
+
+// All matches events matching every filter. It matches everything when
+// no filters are given.
+func All(filters ...Filter) Filter {
+	switch len(filters) {
+	case 0:
+		return func(*Event) bool { return true }
+	case 1:
+		return filters[0]
+	}
+	return func(e *Event) bool {
+		for _, f := range filters {
+			if !f(e) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Any matches events matching at least one of the filters.
+func Any(filters ...Filter) Filter {
+	return func(e *Event) bool {
+		for _, f := range filters {
+			if f(e) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ScopePrefix matches events whose scope starts with prefix.
+func ScopePrefix(prefix ...string) Filter {
+	return func(e *Event) bool {
+		return hasScopePrefix(e.Scope, prefix)
+	}
+}
+
+// NameGlob matches events whose name matches the shell pattern, using
+// the syntax of path.Match.
+func NameGlob(pattern string) (Filter, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid event name pattern %q: %w", pattern, err)
+	}
+	return func(e *Event) bool {
+		ok, _ := path.Match(pattern, e.Name)
+		return ok
+	}, nil
+}
+
+// HasTag matches events having a tag with the given key.
+func HasTag(key string) Filter {
+	return func(e *Event) bool {
+		for _, tag := range e.Tags {
+			if tag.Key == key {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// TagValue matches events having a tag with the given key, whose value
+// formats (see pb.Tag.ValueString) to value.
+func TagValue(key, value string) Filter {
+	return func(e *Event) bool {
+		for _, tag := range e.Tags {
+			if tag.Key == key && tag.ValueString() == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MinSeverity matches events with at least the given severity. Events
+// without a severity only match SeverityUnspecified.
+func MinSeverity(severity Severity) Filter {
+	return func(e *Event) bool {
+		return e.Severity >= severity
+	}
+}
+
+// ParseFilter creates a Filter from comma separated parameters, as used
+// by destination configuration strings. All parameters must match:
+//
+//	scope=storj.io/storj/satellite,scope=metainfo   scope starts with [storj.io/storj/satellite metainfo]
+//	name=billing_*                                  event name matches the glob
+//	tag=bucket                                      event has the tag
+//	tag=env=prod                                    event has the tag with the value
+//	severity=warning                                event has warning or higher severity
+func ParseFilter(params string) (Filter, error) {
+	var scope []string
+	var filters []Filter
+	for param := range strings.SplitSeq(params, ",") {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			return nil, fmt.Errorf("filter parameters should be defined in param=value format, not %q", param)
+		}
+		switch key {
+		case "scope":
+			scope = append(scope, value)
+		case "name":
+			f, err := NameGlob(value)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, f)
+		case "tag":
+			if tagKey, tagValue, found := strings.Cut(value, "="); found {
+				filters = append(filters, TagValue(tagKey, tagValue))
+			} else {
+				filters = append(filters, HasTag(value))
+			}
+		case "severity":
+			severity, err := ParseSeverity(value)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, MinSeverity(severity))
+		default:
+			return nil, fmt.Errorf("unknown filter parameter %q. Please use scope/name/tag/severity", key)
+		}
+	}
+	if len(scope) > 0 {
+		filters = append(filters, ScopePrefix(scope...))
+	}
+	return All(filters...), nil
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter("scope=storj.io/storj/satellite,scope=metainfo,name=billing_*,tag=bucket,tag=env=prod")
+	requireNoError(t, err)
+
+	matching := &Event{
+		Name:  "billing_usage",
+		Scope: []string{"storj.io/storj/satellite", "metainfo", "endpoint"},
+		Tags:  []Tag{String("bucket", "b1"), String("env", "prod")},
+	}
+	requireEqual(t, filter(matching), true)
+
+	for _, modify := range []func(e *Event){
+		func(e *Event) { e.Name = "upload" },
+		func(e *Event) { e.Scope = []string{"storj.io/storj/satellite"} },
+		func(e *Event) { e.Tags = e.Tags[1:] },
+		func(e *Event) { e.Tags = []Tag{String("bucket", "b1"), String("env", "test")} },
+	} {
+		e := *matching
+		modify(&e)
+		requireEqual(t, filter(&e), false)
+	}
+
+	_, err = ParseFilter("unknown=1")
+	requireEqual(t, err != nil, true)
+	_, err = ParseFilter("name=[")
+	requireEqual(t, err != nil, true)
+	_, err = ParseFilter("severity=fatal")
+	requireEqual(t, err != nil, true)
+}
+
+func TestSeverityFilter(t *testing.T) {
+	r := NewRegistry()
+	warnings := &recordingDestination{}
+	filter, err := ParseFilter("severity=WARN")
+	requireNoError(t, err)
+	r.AddDestination(warnings, filter)
+
+	scope := r.Scope("app")
+	scope.Event("plain")
+	scope.Debug("debug")
+	scope.Info("info")
+	scope.Warning("warning")
+	scope.Error("error")
+
+	var names []string
+	for _, e := range warnings.Events() {
+		names = append(names, e.Name+"="+e.Severity.String())
+	}
+	requireEqual(t, names, []string{"warning=WARNING", "error=ERROR"})
+}
+
+func TestRegistryFilteredDestination(t *testing.T) {
+	r := NewRegistry()
+	billing, debug, all := &recordingDestination{}, &recordingDestination{}, &recordingDestination{}
+
+	nameFilter, err := NameGlob("billing_*")
+	requireNoError(t, err)
+	h := r.AddDestination(billing, nameFilter)
+	r.AddDestination(debug, ScopePrefix("debug"))
+	r.AddDestination(all)
+
+	r.Scope("debug").Event("trace")
+	r.Scope("app").Event("billing_usage")
+	r.Scope("app").Event("other")
+
+	requireEqual(t, len(billing.Events()), 1)
+	requireEqual(t, len(debug.Events()), 1)
+	requireEqual(t, len(all.Events()), 3)
+
+	// replacing keeps the filter.
+	replacement := &recordingDestination{}
+	h.Replace(replacement)
+	r.Scope("app").Event("other")
+	r.Scope("app").Event("billing_total")
+	requireEqual(t, len(replacement.Events()), 1)
+}
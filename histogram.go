@@ -0,0 +1,152 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"storj.io/eventkit/pb"
+)
+
+// HistogramScale is the scale of the histograms built by Histogram. With
+// scale 3 the bucket boundaries grow by 2^(1/8), so the relative error of
+// the values estimated from the buckets is below 5%.
+const HistogramScale = 3
+
+// Histogram accumulates values in sparse exponential buckets, to report
+// their distribution in a single tag. The zero value is ready to use, and
+// it's safe for concurrent use.
+//
+//	var latency eventkit.Histogram
+//	for _, op := range batch {
+//		start := time.Now()
+//		op()
+//		latency.ObserveDuration(time.Since(start))
+//	}
+//	scope.Event("batch", latency.Tag("latency"))
+type Histogram struct {
+	mu        sync.Mutex
+	scale     int32
+	count     uint64
+	sum       float64
+	min, max  float64
+	zeroCount uint64
+	buckets   map[int32]uint64
+}
+
+func (h *Histogram) init() {
+	if h.buckets == nil {
+		h.buckets = map[int32]uint64{}
+		if h.count == 0 {
+			h.scale = HistogramScale
+		}
+	}
+}
+
+// Observe adds a value to the histogram. The buckets cover only positive
+// values: zero and negative values are counted in the zero count, while the
+// sum, min and max keep their actual value. NaN values are ignored.
+func (h *Histogram) Observe(v float64) {
+	if math.IsNaN(v) {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.init()
+
+	if h.count == 0 {
+		h.min, h.max = v, v
+	}
+	h.count++
+	h.sum += v
+	h.min = min(h.min, v)
+	h.max = max(h.max, v)
+	if v <= 0 {
+		h.zeroCount++
+		return
+	}
+	h.buckets[bucketIndex(v, h.scale)]++
+}
+
+// ObserveDuration adds a duration to the histogram, in seconds.
+func (h *Histogram) ObserveDuration(d time.Duration) {
+	h.Observe(d.Seconds())
+}
+
+// Merge adds the values of a histogram, for example one received in a tag.
+// When the scales differ, the finer histogram is downscaled.
+func (h *Histogram) Merge(other *pb.Histogram) {
+	if other == nil || other.Count == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.init()
+
+	if h.count == 0 {
+		h.scale = min(h.scale, other.Scale)
+		h.min, h.max = other.Min, other.Max
+	}
+	if other.Scale < h.scale {
+		h.downscale(h.scale - other.Scale)
+	}
+	shift := other.Scale - h.scale
+
+	h.count += other.Count
+	h.sum += other.Sum
+	h.min = min(h.min, other.Min)
+	h.max = max(h.max, other.Max)
+	h.zeroCount += other.ZeroCount
+	for _, b := range other.Buckets {
+		h.buckets[b.Index>>shift] += b.Count
+	}
+}
+
+// downscale merges every 2^by neighbouring buckets.
+func (h *Histogram) downscale(by int32) {
+	buckets := make(map[int32]uint64, len(h.buckets))
+	for index, count := range h.buckets {
+		buckets[index>>by] += count
+	}
+	h.buckets = buckets
+	h.scale -= by
+}
+
+// Snapshot returns the current state of the histogram.
+func (h *Histogram) Snapshot() *pb.Histogram {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.init()
+
+	snapshot := &pb.Histogram{
+		Count:     h.count,
+		Sum:       h.sum,
+		Min:       h.min,
+		Max:       h.max,
+		Scale:     h.scale,
+		ZeroCount: h.zeroCount,
+		Buckets:   make([]*pb.HistogramBucket, 0, len(h.buckets)),
+	}
+	for index, count := range h.buckets {
+		snapshot.Buckets = append(snapshot.Buckets, &pb.HistogramBucket{Index: index, Count: count})
+	}
+	slices.SortFunc(snapshot.Buckets, func(a, b *pb.HistogramBucket) int {
+		return int(a.Index) - int(b.Index)
+	})
+	return snapshot
+}
+
+// Tag returns the current state of the histogram as a tag.
+func (h *Histogram) Tag(key string) Tag {
+	return HistogramTag(key, h.Snapshot())
+}
+
+// bucketIndex returns the index of the bucket of a positive value, such that
+// base^index < v <= base^(index+1).
+func bucketIndex(v float64, scale int32) int32 {
+	return int32(math.Ceil(math.Ldexp(math.Log2(v), int(scale)))) - 1
+}
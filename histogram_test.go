@@ -0,0 +1,81 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"storj.io/eventkit/pb"
+)
+
+func TestHistogramBucketIndex(t *testing.T) {
+	// the buckets are exclusive at the lower and inclusive at the upper
+	// boundary.
+	requireEqual(t, bucketIndex(1, 0), int32(-1))
+	requireEqual(t, bucketIndex(1.5, 0), int32(0))
+	requireEqual(t, bucketIndex(2, 0), int32(0))
+	requireEqual(t, bucketIndex(0.25, 0), int32(-3))
+	requireEqual(t, bucketIndex(1024, 3), int32(79))
+
+	for _, v := range []float64{1e-9, 0.003, 0.5, 7, 12345} {
+		index := bucketIndex(v, HistogramScale)
+		base := math.Pow(2, math.Pow(2, -HistogramScale))
+		if lower, upper := math.Pow(base, float64(index)), math.Pow(base, float64(index+1)); v <= lower*0.9999999 || v > upper*1.0000001 {
+			t.Fatalf("%v not in (%v, %v]", v, lower, upper)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	var h Histogram
+	requireEqual(t, h.Snapshot().Count, uint64(0))
+
+	h.ObserveDuration(time.Second)
+	h.Observe(2)
+	h.Observe(2)
+	h.Observe(0)
+	h.Observe(math.NaN())
+
+	s := h.Snapshot()
+	requireEqual(t, s.Count, uint64(4))
+	requireEqual(t, s.Sum, 5.0)
+	requireEqual(t, s.Min, 0.0)
+	requireEqual(t, s.Max, 2.0)
+	requireEqual(t, s.Scale, int32(HistogramScale))
+	requireEqual(t, s.ZeroCount, uint64(1))
+	requireEqual(t, s.Buckets, []*pb.HistogramBucket{{Index: -1, Count: 1}, {Index: 7, Count: 2}})
+
+	tag := h.Tag("latency")
+	requireEqual(t, tag.ValueString(),
+		"{count=4,sum=5,min=0,max=2,scale=3,zero_count=1,buckets=[{index=-1,count=1},{index=7,count=2}]}")
+}
+
+func TestHistogramMerge(t *testing.T) {
+	var h Histogram
+	h.Observe(3)
+	h.Merge(&pb.Histogram{
+		Count: 2, Sum: 9, Min: 1.5, Max: 7.5, Scale: 1,
+		Buckets: []*pb.HistogramBucket{{Index: 1, Count: 1}, {Index: 5, Count: 1}},
+	})
+
+	s := h.Snapshot()
+	requireEqual(t, s.Count, uint64(3))
+	requireEqual(t, s.Sum, 12.0)
+	requireEqual(t, s.Min, 1.5)
+	requireEqual(t, s.Max, 7.5)
+	// 3 is in bucket 12 at scale 3, which is bucket 3 at scale 1.
+	requireEqual(t, s.Scale, int32(1))
+	requireEqual(t, s.Buckets, []*pb.HistogramBucket{{Index: 1, Count: 1}, {Index: 3, Count: 1}, {Index: 5, Count: 1}})
+
+	// finer histograms are downscaled.
+	h.Merge(&pb.Histogram{
+		Count: 1, Sum: 3, Min: 3, Max: 3, Scale: 3,
+		Buckets: []*pb.HistogramBucket{{Index: 12, Count: 1}},
+	})
+	s = h.Snapshot()
+	requireEqual(t, s.Count, uint64(4))
+	requireEqual(t, s.Buckets[1], &pb.HistogramBucket{Index: 3, Count: 2})
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"regexp"
+	"strings"
+
+	"storj.io/eventkit/pb"
+)
+
+// Middleware transforms an Event before it is handed to the destinations
+// of a Registry. Returning nil drops the event.
+//
+// The received event may share its Scope and Tags with the caller, so
+// middlewares must not modify them in place. Build new slices (and new
+// tags) instead.
+type Middleware func(*Event) *Event
+
+// RedactedValue is the value RedactTags uses for redacted tags.
+const RedactedValue = "<redacted>"
+
+// RedactTags replaces the value of every tag whose key matches pattern
+// with RedactedValue.
+func RedactTags(pattern *regexp.Regexp) Middleware {
+	return func(e *Event) *Event {
+		return mapTags(e, func(tag Tag) Tag {
+			if !pattern.MatchString(tag.Key) {
+				return tag
+			}
+			return String(tag.Key, RedactedValue)
+		})
+	}
+}
+
+// MapTagKeys renames the tags of every event with fn.
+func MapTagKeys(fn func(key string) string) Middleware {
+	return func(e *Event) *Event {
+		return mapTags(e, func(tag Tag) Tag {
+			key := fn(tag.Key)
+			if key == tag.Key {
+				return tag
+			}
+			return &pb.Tag{Key: key, Value: tag.Value}
+		})
+	}
+}
+
+// NormalizeTagKeys lowercases tag keys and replaces every character
+// other than a-z, 0-9 and '_' with '_'.
+func NormalizeTagKeys() Middleware {
+	return MapTagKeys(normalizeKey)
+}
+
+func normalizeKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', '0' <= r && r <= '9', r == '_':
+			return r
+		case 'A' <= r && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return '_'
+		}
+	}, key)
+}
+
+// RewriteScope replaces the scope of every event with the result of fn.
+// fn must return a new slice rather than modifying its argument.
+func RewriteScope(fn func(scope []string) []string) Middleware {
+	return func(e *Event) *Event {
+		c := *e
+		c.Scope = fn(e.Scope)
+		return &c
+	}
+}
+
+// ReplaceScopePrefix rewrites scopes starting with from to start with to
+// instead.
+func ReplaceScopePrefix(from, to []string) Middleware {
+	return RewriteScope(func(scope []string) []string {
+		if !hasScopePrefix(scope, from) {
+			return scope
+		}
+		return append(append([]string(nil), to...), scope[len(from):]...)
+	})
+}
+
+// DropIf drops every event for which pred returns true.
+func DropIf(pred func(*Event) bool) Middleware {
+	return func(e *Event) *Event {
+		if pred(e) {
+			return nil
+		}
+		return e
+	}
+}
+
+// mapTags returns a copy of e with fn applied to every tag. e itself is
+// returned when fn didn't change anything.
+func mapTags(e *Event, fn func(Tag) Tag) *Event {
+	var tags []Tag
+	for i, tag := range e.Tags {
+		mapped := fn(tag)
+		if tags == nil {
+			if mapped == tag {
+				continue
+			}
+			tags = make([]Tag, len(e.Tags))
+			copy(tags, e.Tags[:i])
+		}
+		tags[i] = mapped
+	}
+	if tags == nil {
+		return e
+	}
+	c := *e
+	c.Tags = tags
+	return &c
+}
+
+func hasScopePrefix(scope, prefix []string) bool {
+	if len(prefix) > len(scope) {
+		return false
+	}
+	for i := range prefix {
+		if scope[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
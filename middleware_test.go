@@ -0,0 +1,75 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordingDestination struct {
+	mu     sync.Mutex
+	events []*Event
+}
+
+func (d *recordingDestination) Submit(events ...*Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, events...)
+}
+
+func (d *recordingDestination) Run(ctx context.Context) {}
+
+func (d *recordingDestination) Events() []*Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*Event(nil), d.events...)
+}
+
+func TestMiddlewareChain(t *testing.T) {
+	r := NewRegistry()
+	dest := &recordingDestination{}
+	r.AddDestination(dest)
+
+	r.Use(
+		DropIf(func(e *Event) bool { return e.Name == "debug" }),
+		NormalizeTagKeys(),
+		RedactTags(regexp.MustCompile(`^(password|token)$`)),
+		ReplaceScopePrefix([]string{"old"}, []string{"new", "prefix"}),
+	)
+
+	scope := r.Scope("old").Subscope("sub")
+	scope.Event("debug", String("a", "b"))
+	scope.Event("login", String("User-Name", "alice"), String("Password", "hunter2"))
+
+	events := dest.Events()
+	requireEqual(t, len(events), 1)
+	requireEqual(t, events[0].Name, "login")
+	requireEqual(t, events[0].Scope, []string{"new", "prefix", "sub"})
+	requireEqual(t, len(events[0].Tags), 2)
+	requireEqual(t, events[0].Tags[0].KVString(), "user_name=alice")
+	requireEqual(t, events[0].Tags[1].KVString(), "password="+RedactedValue)
+
+	// the scope itself must not be modified by the rewrite.
+	requireEqual(t, scope.name, []string{"old", "sub"})
+}
+
+func TestMiddlewareDoesNotModifyInput(t *testing.T) {
+	tags := []Tag{String("Key", "value")}
+	in := &Event{Name: "e", Scope: []string{"a", "b"}, Tags: tags}
+
+	out := MapTagKeys(strings.ToLower)(in)
+	requireEqual(t, out.Tags[0].Key, "key")
+	requireEqual(t, in.Tags[0].Key, "Key")
+
+	out = RewriteScope(func(scope []string) []string { return []string{"c"} })(in)
+	requireEqual(t, out.Scope, []string{"c"})
+	requireEqual(t, in.Scope, []string{"a", "b"})
+
+	// unchanged events are passed through as is.
+	requireEqual(t, RedactTags(regexp.MustCompile("secret"))(in) == in, true)
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+//go:build linux
+
+package eventkit
+
+import (
+	"net"
+	"syscall"
+)
+
+// pathMTU returns the path MTU of the connected socket, known by the kernel.
+func pathMTU(conn *net.UDPConn) (mtu int, ok bool) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return 0, false
+	}
+
+	level, opt := syscall.IPPROTO_IP, syscall.IP_MTU
+	if addr, isUDP := conn.RemoteAddr().(*net.UDPAddr); isUDP && addr.IP.To4() == nil {
+		level, opt = syscall.IPPROTO_IPV6, syscall.IPV6_MTU
+	}
+
+	var sockErr error
+	err = raw.Control(func(fd uintptr) {
+		mtu, sockErr = syscall.GetsockoptInt(int(fd), level, opt)
+	})
+	if err != nil || sockErr != nil || mtu <= ipUDPHeaderSize {
+		return 0, false
+	}
+	return mtu, true
+}
@@ -0,0 +1,13 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+//go:build !linux
+
+package eventkit
+
+import "net"
+
+// pathMTU isn't supported on this platform.
+func pathMTU(conn *net.UDPConn) (mtu int, ok bool) {
+	return 0, false
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"storj.io/eventkit"
+)
+
+func init() {
+	eventkit.RegisterDestinationType(eventkit.DestinationType{
+		Name:         "otlp",
+		Params:       []string{"endpoint", "application", "version", "instance", "timeout", "header"},
+		DefaultParam: "endpoint",
+		Create:       create,
+	})
+}
+
+// create creates the destination of an otlp layer. Headers are given as header=Name:value, and can be repeated.
+func create(ctx context.Context, params eventkit.LayerParams, next func() (eventkit.Destination, error)) (eventkit.Destination, error) {
+	endpoint, found := params.Get("endpoint")
+	if !found {
+		return nil, errors.New("endpoint parameter is required")
+	}
+	timeout, err := params.Duration("timeout", defaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+	instance, found := params.Get("instance")
+	if !found {
+		instance, _ = os.Hostname()
+	}
+	application := params.String("application", filepath.Base(os.Args[0]))
+
+	d := NewDestination(endpoint, application, params.String("version", ""), instance).WithTimeout(timeout)
+	for _, param := range params {
+		if param.Key != "header" {
+			continue
+		}
+		key, value, found := strings.Cut(param.Value, ":")
+		if !found {
+			return nil, fmt.Errorf("header parameter should be defined in Name:value format, not %s", param.Value)
+		}
+		d = d.WithHeader(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+	return d, nil
+}
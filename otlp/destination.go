@@ -0,0 +1,311 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package otlp exports eventkit events as OpenTelemetry log records, with the OTLP/HTTP protocol.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+
+	"storj.io/eventkit"
+	"storj.io/eventkit/destination"
+	"storj.io/eventkit/pb"
+	"storj.io/picobuf"
+)
+
+var mon = monkit.Package()
+
+const (
+	// TraceIDTag is the tag which is exported as the trace id of the log record. The value is either 16 bytes, or
+	// their hex encoding.
+	TraceIDTag = "trace_id"
+	// SpanIDTag is the tag which is exported as the span id of the log record. The value is either 8 bytes, or their
+	// hex encoding.
+	SpanIDTag = "span_id"
+
+	// EventNameAttribute is the attribute holding the name of the event.
+	EventNameAttribute = "event.name"
+	// EventIDAttribute is the attribute holding the unique ID of the event, when it's set.
+	EventIDAttribute = "log.record.uid"
+
+	defaultTimeout  = 10 * time.Second
+	logsPath        = "/v1/logs"
+	maxErrorMessage = 1024
+)
+
+// Destination sends the events to an OpenTelemetry collector as log records.
+//
+// The scope of the event is the instrumentation scope of the record (joined with dots), the name is the event.name
+// attribute, the ID is the log.record.uid attribute and the tags are typed attributes. Durations are exported as
+// integer nanoseconds, timestamps as RFC 3339 strings, lists and maps as array and key-value list values. The trace_id
+// and span_id tags become the trace context of the record.
+//
+// Application, version and instance are the service.name, service.version and service.instance.id resource
+// attributes. The severity of the event is the severity number and text of the record.
+type Destination struct {
+	endpoint string
+	client   *http.Client
+	headers  http.Header
+	resource *Resource
+
+	stats eventkit.StatsCounter
+}
+
+// severityNumbers maps the severities to the OpenTelemetry severity numbers.
+var severityNumbers = map[eventkit.Severity]int32{
+	eventkit.SeverityDebug:   5,
+	eventkit.SeverityInfo:    9,
+	eventkit.SeverityWarning: 13,
+	eventkit.SeverityError:   17,
+}
+
+var _ eventkit.Destination = &Destination{}
+var _ eventkit.Sender = &Destination{}
+var _ eventkit.StatsReporter = &Destination{}
+
+// NewDestination creates a destination exporting to the collector at endpoint. An endpoint without a path is
+// completed with the default /v1/logs path, and without a scheme with http://.
+func NewDestination(endpoint, application, version, instance string) *Destination {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	if scheme, rest, _ := strings.Cut(endpoint, "://"); !strings.Contains(rest, "/") {
+		endpoint = scheme + "://" + rest + logsPath
+	}
+
+	resource := &Resource{}
+	for _, attr := range []struct{ key, value string }{
+		{"service.name", application},
+		{"service.version", version},
+		{"service.instance.id", instance},
+	} {
+		if attr.value != "" {
+			resource.Attributes = append(resource.Attributes, &KeyValue{
+				Key:   attr.key,
+				Value: &AnyValue{Value: &AnyValue_StringValue{StringValue: attr.value}},
+			})
+		}
+	}
+
+	return &Destination{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: defaultTimeout},
+		headers:  http.Header{},
+		resource: resource,
+	}
+}
+
+// WithHeader adds a header to the export requests, for example for authentication.
+//
+// It must be called before Run and Submit.
+func (d *Destination) WithHeader(key, value string) *Destination {
+	d.headers.Add(key, value)
+	return d
+}
+
+// WithTimeout sets the timeout of the export requests.
+//
+// It must be called before Run and Submit.
+func (d *Destination) WithTimeout(timeout time.Duration) *Destination {
+	d.client.Timeout = timeout
+	return d
+}
+
+// Submit implements eventkit.Destination.
+func (d *Destination) Submit(events ...*eventkit.Event) {
+	if err := d.Send(events...); err != nil {
+		mon.Counter("dropped_events").Inc(int64(len(events)))
+	}
+}
+
+// Send implements eventkit.Sender. Requests rejected by the collector are marked with destination.Permanent.
+func (d *Destination) Send(events ...*eventkit.Event) (err error) {
+	defer mon.Task()(nil)(&err)
+
+	data, err := picobuf.Marshal(d.request(events, time.Now()))
+	if err != nil {
+		d.stats.Failed(len(events), err)
+		return destination.Permanent(err)
+	}
+
+	err = d.post(data)
+	if err != nil {
+		d.stats.Failed(len(events), err)
+		return err
+	}
+	d.stats.Sent(len(events))
+	d.stats.BytesSent(len(data))
+	return nil
+}
+
+func (d *Destination) post(data []byte) error {
+	req, err := http.NewRequest(http.MethodPost, d.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return destination.Permanent(err)
+	}
+	for key, values := range d.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 == 2 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorMessage))
+	err = fmt.Errorf("otlp export failed with %s: %s", resp.Status, bytes.TrimSpace(message))
+	switch resp.StatusCode {
+	// retryable responses of the OTLP/HTTP specification.
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return err
+	default:
+		return destination.Permanent(err)
+	}
+}
+
+// request converts the events, grouping them by scope.
+func (d *Destination) request(events []*eventkit.Event, now time.Time) *ExportLogsServiceRequest {
+	var scopes []*ScopeLogs
+	byScope := map[string]*ScopeLogs{}
+	for _, e := range events {
+		name := strings.Join(e.Scope, ".")
+		scope, found := byScope[name]
+		if !found {
+			scope = &ScopeLogs{Scope: &InstrumentationScope{Name: name}}
+			byScope[name] = scope
+			scopes = append(scopes, scope)
+		}
+		scope.LogRecords = append(scope.LogRecords, logRecord(e, now))
+	}
+	return &ExportLogsServiceRequest{
+		ResourceLogs: []*ResourceLogs{{
+			Resource:  d.resource,
+			ScopeLogs: scopes,
+		}},
+	}
+}
+
+func logRecord(e *eventkit.Event, now time.Time) *LogRecord {
+	record := &LogRecord{
+		ObservedTimeUnixNano: uint64(now.UnixNano()),
+		EventName:            e.Name,
+		Body:                 &AnyValue{Value: &AnyValue_StringValue{StringValue: e.Name}},
+		Attributes: []*KeyValue{{
+			Key:   EventNameAttribute,
+			Value: &AnyValue{Value: &AnyValue_StringValue{StringValue: e.Name}},
+		}},
+	}
+	if !e.Timestamp.IsZero() {
+		record.TimeUnixNano = uint64(e.Timestamp.UnixNano())
+	}
+	if number, ok := severityNumbers[e.Severity]; ok {
+		record.SeverityNumber = number
+		record.SeverityText = e.Severity.String()
+	}
+	if !e.ID.IsZero() {
+		record.Attributes = append(record.Attributes, &KeyValue{
+			Key:   EventIDAttribute,
+			Value: &AnyValue{Value: &AnyValue_StringValue{StringValue: e.ID.String()}},
+		})
+	}
+
+	for _, tag := range e.Tags {
+		switch tag.Key {
+		case TraceIDTag:
+			if id, ok := traceContextID(tag, 16); ok {
+				record.TraceId = id
+				continue
+			}
+		case SpanIDTag:
+			if id, ok := traceContextID(tag, 8); ok {
+				record.SpanId = id
+				continue
+			}
+		}
+		if value := attributeValue(tag); value != nil {
+			record.Attributes = append(record.Attributes, &KeyValue{Key: tag.Key, Value: value})
+		}
+	}
+	return record
+}
+
+// traceContextID returns the id of the given size from a bytes or a hex encoded string tag.
+func traceContextID(tag eventkit.Tag, size int) ([]byte, bool) {
+	var id []byte
+	switch v := tag.Value.(type) {
+	case *pb.Tag_Bytes:
+		id = v.Bytes
+	case *pb.Tag_String_:
+		decoded, err := hex.DecodeString(string(v.String_))
+		if err != nil {
+			return nil, false
+		}
+		id = decoded
+	}
+	return id, len(id) == size
+}
+
+func attributeValue(tag eventkit.Tag) *AnyValue {
+	switch v := tag.Value.(type) {
+	case *pb.Tag_String_:
+		return &AnyValue{Value: &AnyValue_StringValue{StringValue: string(v.String_)}}
+	case *pb.Tag_Int64:
+		return &AnyValue{Value: &AnyValue_IntValue{IntValue: v.Int64}}
+	case *pb.Tag_Double:
+		return &AnyValue{Value: &AnyValue_DoubleValue{DoubleValue: v.Double}}
+	case *pb.Tag_Bool:
+		return &AnyValue{Value: &AnyValue_BoolValue{BoolValue: v.Bool}}
+	case *pb.Tag_Bytes:
+		return &AnyValue{Value: &AnyValue_BytesValue{BytesValue: v.Bytes}}
+	case *pb.Tag_DurationNs:
+		return &AnyValue{Value: &AnyValue_IntValue{IntValue: v.DurationNs}}
+	case *pb.Tag_Timestamp:
+		return &AnyValue{Value: &AnyValue_StringValue{StringValue: v.Timestamp.AsTime().Format(time.RFC3339Nano)}}
+	case *pb.Tag_List:
+		array := &ArrayValue{}
+		for _, value := range v.List.GetValues() {
+			if value := attributeValue(value); value != nil {
+				array.Values = append(array.Values, value)
+			}
+		}
+		return &AnyValue{Value: &AnyValue_ArrayValue{ArrayValue: array}}
+	case *pb.Tag_Map:
+		kvlist := &KeyValueList{}
+		for _, nested := range v.Map.GetTags() {
+			if value := attributeValue(nested); value != nil {
+				kvlist.Values = append(kvlist.Values, &KeyValue{Key: nested.Key, Value: value})
+			}
+		}
+		return &AnyValue{Value: &AnyValue_KvlistValue{KvlistValue: kvlist}}
+	case *pb.Tag_Histogram:
+		return attributeValue(&pb.Tag{Value: &pb.Tag_Map{Map: v.Histogram.AsMap()}})
+	default:
+		return nil
+	}
+}
+
+// Run implements eventkit.Destination.
+func (d *Destination) Run(ctx context.Context) {
+	<-ctx.Done()
+	d.client.CloseIdleConnections()
+}
+
+// Stats implements eventkit.StatsReporter.
+func (d *Destination) Stats() eventkit.Stats {
+	return d.stats.Snapshot(0)
+}
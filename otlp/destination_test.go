@@ -0,0 +1,153 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package otlp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit"
+	"storj.io/eventkit/destination"
+	"storj.io/picobuf"
+)
+
+type collector struct {
+	mu       sync.Mutex
+	status   int
+	requests []*ExportLogsServiceRequest
+	headers  []http.Header
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r.URL.Path != "/v1/logs" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+		http.Error(w, "unexpected request", http.StatusNotFound)
+		return
+	}
+	if c.status != 0 {
+		http.Error(w, "rejected", c.status)
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var req ExportLogsServiceRequest
+	if err := picobuf.Unmarshal(data, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.requests = append(c.requests, &req)
+	c.headers = append(c.headers, r.Header)
+}
+
+func TestDestination(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	d := NewDestination(strings.TrimPrefix(server.URL, "http://"), "app", "v1", "instance1").
+		WithHeader("Authorization", "Bearer token")
+
+	ts := time.Date(2026, 10, 18, 13, 5, 0, 0, time.UTC)
+	err := d.Send(
+		&eventkit.Event{Name: "upload", Scope: []string{"storj.io/uplink"}, Timestamp: ts, ID: eventkit.EventID{Session: 1, Sequence: 2}, Tags: []eventkit.Tag{
+			eventkit.Int64("size", 10),
+			eventkit.Strings("labels", []string{"a"}),
+			eventkit.Map("user", eventkit.Int64("id", 1)),
+			eventkit.Duration("took", time.Second),
+			eventkit.String(TraceIDTag, "0102030405060708090a0b0c0d0e0f10"),
+			eventkit.Bytes(SpanIDTag, []byte{1, 2, 3, 4, 5, 6, 7, 8}),
+		}},
+		&eventkit.Event{Name: "download", Scope: []string{"storj.io/uplink"}, Timestamp: ts, Severity: eventkit.SeverityWarning, Tags: []eventkit.Tag{
+			eventkit.String(TraceIDTag, "not hex"),
+		}},
+		&eventkit.Event{Name: "audit", Scope: []string{"storj.io/satellite"}, Timestamp: ts},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, c.requests, 1)
+	require.Equal(t, "Bearer token", c.headers[0].Get("Authorization"))
+	resourceLogs := c.requests[0].ResourceLogs
+	require.Len(t, resourceLogs, 1)
+	require.Equal(t, "service.name", resourceLogs[0].Resource.Attributes[0].Key)
+	require.Equal(t, &AnyValue_StringValue{StringValue: "app"}, resourceLogs[0].Resource.Attributes[0].Value.Value)
+
+	scopes := resourceLogs[0].ScopeLogs
+	require.Len(t, scopes, 2)
+	require.Equal(t, "storj.io/uplink", scopes[0].Scope.Name)
+	require.Equal(t, "storj.io/satellite", scopes[1].Scope.Name)
+	require.Len(t, scopes[0].LogRecords, 2)
+
+	upload := scopes[0].LogRecords[0]
+	require.Equal(t, uint64(ts.UnixNano()), upload.TimeUnixNano)
+	require.Equal(t, "upload", upload.EventName)
+	require.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, upload.TraceId)
+	require.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, upload.SpanId)
+	require.Zero(t, upload.SeverityNumber)
+	attributes := map[string]isAnyValue_Value{}
+	for _, kv := range upload.Attributes {
+		attributes[kv.Key] = kv.Value.Value
+	}
+	require.Equal(t, map[string]isAnyValue_Value{
+		EventNameAttribute: &AnyValue_StringValue{StringValue: "upload"},
+		EventIDAttribute:   &AnyValue_StringValue{StringValue: "0000000000000001-2"},
+		"size":             &AnyValue_IntValue{IntValue: 10},
+		"took":             &AnyValue_IntValue{IntValue: int64(time.Second)},
+		"labels": &AnyValue_ArrayValue{ArrayValue: &ArrayValue{Values: []*AnyValue{
+			{Value: &AnyValue_StringValue{StringValue: "a"}},
+		}}},
+		"user": &AnyValue_KvlistValue{KvlistValue: &KeyValueList{Values: []*KeyValue{
+			{Key: "id", Value: &AnyValue{Value: &AnyValue_IntValue{IntValue: 1}}},
+		}}},
+	}, attributes)
+
+	// an invalid trace id is kept as an attribute.
+	download := scopes[0].LogRecords[1]
+	require.Nil(t, download.TraceId)
+	require.Equal(t, int32(13), download.SeverityNumber)
+	require.Equal(t, "WARNING", download.SeverityText)
+	require.Equal(t, TraceIDTag, download.Attributes[1].Key)
+
+	require.Equal(t, int64(3), d.Stats().Sent)
+}
+
+func TestDestinationErrors(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+	d := NewDestination(server.URL, "app", "", "")
+
+	c.status = http.StatusServiceUnavailable
+	err := d.Send(&eventkit.Event{Name: "a"})
+	require.Error(t, err)
+	require.False(t, destination.IsPermanent(err))
+
+	c.status = http.StatusBadRequest
+	err = d.Send(&eventkit.Event{Name: "a"})
+	require.Error(t, err)
+	require.True(t, destination.IsPermanent(err))
+
+	require.Equal(t, int64(2), d.Stats().Failed)
+}
+
+func TestCreateDestination(t *testing.T) {
+	dest, err := eventkit.CreateDestination(t.Context(), "otlp:localhost:4318,application=app,header=Authorization:Bearer token|retry")
+	require.NoError(t, err)
+	retry, ok := dest.(*destination.Retry)
+	require.True(t, ok)
+	require.NotNil(t, retry)
+
+	_, err = eventkit.CreateDestination(t.Context(), "otlp:localhost:4318,header=invalid")
+	require.Error(t, err)
+}
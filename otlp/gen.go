@@ -0,0 +1,3 @@
+package otlp
+
+//go:generate protoc --pico_out=paths=source_relative:. -I=. logs.proto
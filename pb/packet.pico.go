@@ -8,9 +8,38 @@
 package pb
 
 import (
+	strconv "strconv"
+
 	picobuf "storj.io/picobuf"
 )
 
+type Severity int32
+
+const (
+	Severity_UNSPECIFIED Severity = 0
+	Severity_DEBUG       Severity = 1
+	Severity_INFO        Severity = 2
+	Severity_WARNING     Severity = 3
+	Severity_ERROR       Severity = 4
+)
+
+func (m Severity) String() string {
+	switch m {
+	case Severity_UNSPECIFIED:
+		return "UNSPECIFIED"
+	case Severity_DEBUG:
+		return "DEBUG"
+	case Severity_INFO:
+		return "INFO"
+	case Severity_WARNING:
+		return "WARNING"
+	case Severity_ERROR:
+		return "ERROR"
+	default:
+		return "Severity(" + strconv.Itoa(int(m)) + ")"
+	}
+}
+
 type Timestamp struct {
 	Seconds int64 `json:"seconds,omitempty"`
 	Nanos   int32 `json:"nanos,omitempty"`
@@ -64,6 +93,15 @@ func (m *Tag) Encode(c *picobuf.Encoder) bool {
 	if m, ok := m.Value.(*Tag_Timestamp); ok {
 		c.Message(8, m.Timestamp.Encode)
 	}
+	if m, ok := m.Value.(*Tag_List); ok {
+		c.Message(9, m.List.Encode)
+	}
+	if m, ok := m.Value.(*Tag_Map); ok {
+		c.Message(10, m.Map.Encode)
+	}
+	if m, ok := m.Value.(*Tag_Histogram); ok {
+		c.Message(11, m.Histogram.Encode)
+	}
 	return true
 }
 
@@ -154,6 +192,54 @@ func (m *Tag) Decode(c *picobuf.Decoder) {
 			m.Timestamp.Decode(c)
 		})
 	}
+	if c.PendingField() == 9 {
+		var x *Tag_List
+		if z, ok := m.Value.(*Tag_List); ok {
+			x = z
+		} else {
+			x = new(Tag_List)
+			m.Value = x
+		}
+		m := x
+		c.Message(9, func(c *picobuf.Decoder) {
+			if m.List == nil {
+				m.List = new(TagList)
+			}
+			m.List.Decode(c)
+		})
+	}
+	if c.PendingField() == 10 {
+		var x *Tag_Map
+		if z, ok := m.Value.(*Tag_Map); ok {
+			x = z
+		} else {
+			x = new(Tag_Map)
+			m.Value = x
+		}
+		m := x
+		c.Message(10, func(c *picobuf.Decoder) {
+			if m.Map == nil {
+				m.Map = new(TagMap)
+			}
+			m.Map.Decode(c)
+		})
+	}
+	if c.PendingField() == 11 {
+		var x *Tag_Histogram
+		if z, ok := m.Value.(*Tag_Histogram); ok {
+			x = z
+		} else {
+			x = new(Tag_Histogram)
+			m.Value = x
+		}
+		m := x
+		c.Message(11, func(c *picobuf.Decoder) {
+			if m.Histogram == nil {
+				m.Histogram = new(Histogram)
+			}
+			m.Histogram.Decode(c)
+		})
+	}
 }
 
 type isTag_Value interface{ isTag_Value() }
@@ -186,6 +272,18 @@ type Tag_Timestamp struct {
 	Timestamp *Timestamp
 }
 
+type Tag_List struct {
+	List *TagList
+}
+
+type Tag_Map struct {
+	Map *TagMap
+}
+
+type Tag_Histogram struct {
+	Histogram *Histogram
+}
+
 func (*Tag_String_) isTag_Value()    {}
 func (*Tag_Int64) isTag_Value()      {}
 func (*Tag_Double) isTag_Value()     {}
@@ -193,12 +291,133 @@ func (*Tag_Bytes) isTag_Value()      {}
 func (*Tag_Bool) isTag_Value()       {}
 func (*Tag_DurationNs) isTag_Value() {}
 func (*Tag_Timestamp) isTag_Value()  {}
+func (*Tag_List) isTag_Value()       {}
+func (*Tag_Map) isTag_Value()        {}
+func (*Tag_Histogram) isTag_Value()  {}
+
+type TagList struct {
+	Values []*Tag `json:"values,omitempty"`
+}
+
+func (m *TagList) Encode(c *picobuf.Encoder) bool {
+	if m == nil {
+		return false
+	}
+	for _, x := range m.Values {
+		c.AlwaysMessage(1, x.Encode)
+	}
+	return true
+}
+
+func (m *TagList) Decode(c *picobuf.Decoder) {
+	if m == nil {
+		return
+	}
+	c.RepeatedMessage(1, func(c *picobuf.Decoder) {
+		x := new(Tag)
+		c.Loop(x.Decode)
+		m.Values = append(m.Values, x)
+	})
+}
+
+type TagMap struct {
+	Tags []*Tag `json:"tags,omitempty"`
+}
+
+func (m *TagMap) Encode(c *picobuf.Encoder) bool {
+	if m == nil {
+		return false
+	}
+	for _, x := range m.Tags {
+		c.AlwaysMessage(1, x.Encode)
+	}
+	return true
+}
+
+func (m *TagMap) Decode(c *picobuf.Decoder) {
+	if m == nil {
+		return
+	}
+	c.RepeatedMessage(1, func(c *picobuf.Decoder) {
+		x := new(Tag)
+		c.Loop(x.Decode)
+		m.Tags = append(m.Tags, x)
+	})
+}
+
+type Histogram struct {
+	Count     uint64             `json:"count,omitempty"`
+	Sum       float64            `json:"sum,omitempty"`
+	Min       float64            `json:"min,omitempty"`
+	Max       float64            `json:"max,omitempty"`
+	Scale     int32              `json:"scale,omitempty"`
+	ZeroCount uint64             `json:"zero_count,omitempty"`
+	Buckets   []*HistogramBucket `json:"buckets,omitempty"`
+}
+
+func (m *Histogram) Encode(c *picobuf.Encoder) bool {
+	if m == nil {
+		return false
+	}
+	c.Uint64(1, &m.Count)
+	c.Double(2, &m.Sum)
+	c.Double(3, &m.Min)
+	c.Double(4, &m.Max)
+	c.Sint32(5, &m.Scale)
+	c.Uint64(6, &m.ZeroCount)
+	for _, x := range m.Buckets {
+		c.AlwaysMessage(7, x.Encode)
+	}
+	return true
+}
+
+func (m *Histogram) Decode(c *picobuf.Decoder) {
+	if m == nil {
+		return
+	}
+	c.Uint64(1, &m.Count)
+	c.Double(2, &m.Sum)
+	c.Double(3, &m.Min)
+	c.Double(4, &m.Max)
+	c.Sint32(5, &m.Scale)
+	c.Uint64(6, &m.ZeroCount)
+	c.RepeatedMessage(7, func(c *picobuf.Decoder) {
+		x := new(HistogramBucket)
+		c.Loop(x.Decode)
+		m.Buckets = append(m.Buckets, x)
+	})
+}
+
+type HistogramBucket struct {
+	Index int32  `json:"index,omitempty"`
+	Count uint64 `json:"count,omitempty"`
+}
+
+func (m *HistogramBucket) Encode(c *picobuf.Encoder) bool {
+	if m == nil {
+		return false
+	}
+	c.Sint32(1, &m.Index)
+	c.Uint64(2, &m.Count)
+	return true
+}
+
+func (m *HistogramBucket) Decode(c *picobuf.Decoder) {
+	if m == nil {
+		return
+	}
+	c.Sint32(1, &m.Index)
+	c.Uint64(2, &m.Count)
+}
 
 type Event struct {
 	Name              string   `json:"name,omitempty"`
 	Scope             []string `json:"scope,omitempty"`
 	TimestampOffsetNs int64    `json:"timestamp_offset_ns,omitempty"`
 	Tags              []*Tag   `json:"tags,omitempty"`
+	SessionId         uint64   `json:"session_id,omitempty"`
+	Sequence          uint64   `json:"sequence,omitempty"`
+	Severity          Severity `json:"severity,omitempty"`
 }
 
 func (m *Event) Encode(c *picobuf.Encoder) bool {
@@ -211,6 +430,9 @@ func (m *Event) Encode(c *picobuf.Encoder) bool {
 	for _, x := range m.Tags {
 		c.AlwaysMessage(4, x.Encode)
 	}
+	c.Fixed64(5, &m.SessionId)
+	c.Uint64(6, &m.Sequence)
+	c.Int32(7, (*int32)(&m.Severity))
 	return true
 }
 
@@ -226,6 +448,37 @@ func (m *Event) Decode(c *picobuf.Decoder) {
 		c.Loop(x.Decode)
 		m.Tags = append(m.Tags, x)
 	})
+	c.Fixed64(5, &m.SessionId)
+	c.Uint64(6, &m.Sequence)
+	c.Int32(7, (*int32)(&m.Severity))
+}
+
+type Fragment struct {
+	Id    uint64 `json:"id,omitempty"`
+	Index uint32 `json:"index,omitempty"`
+	Count uint32 `json:"count,omitempty"`
+	Data  []byte `json:"data,omitempty"`
+}
+
+func (m *Fragment) Encode(c *picobuf.Encoder) bool {
+	if m == nil {
+		return false
+	}
+	c.Fixed64(1, &m.Id)
+	c.Uint32(2, &m.Index)
+	c.Uint32(3, &m.Count)
+	c.Bytes(4, &m.Data)
+	return true
+}
+
+func (m *Fragment) Decode(c *picobuf.Decoder) {
+	if m == nil {
+		return
+	}
+	c.Fixed64(1, &m.Id)
+	c.Uint32(2, &m.Index)
+	c.Uint32(3, &m.Count)
+	c.Bytes(4, &m.Data)
 }
 
 type Packet struct {
@@ -235,6 +488,8 @@ type Packet struct {
 	StartTimestamp     *Timestamp `json:"start_timestamp,omitempty"`
 	SendOffsetNs       int64      `json:"send_offset_ns,omitempty"`
 	Events             []*Event   `json:"events,omitempty"`
+	Fragment           *Fragment  `json:"fragment,omitempty"`
+	Tags               []*Tag     `json:"tags,omitempty"`
 }
 
 func (m *Packet) Encode(c *picobuf.Encoder) bool {
@@ -249,6 +504,10 @@ func (m *Packet) Encode(c *picobuf.Encoder) bool {
 	for _, x := range m.Events {
 		c.AlwaysMessage(6, x.Encode)
 	}
+	c.Message(7, m.Fragment.Encode)
+	for _, x := range m.Tags {
+		c.AlwaysMessage(8, x.Encode)
+	}
 	return true
 }
 
@@ -271,6 +530,17 @@ func (m *Packet) Decode(c *picobuf.Decoder) {
 		c.Loop(x.Decode)
 		m.Events = append(m.Events, x)
 	})
+	c.Message(7, func(c *picobuf.Decoder) {
+		if m.Fragment == nil {
+			m.Fragment = new(Fragment)
+		}
+		m.Fragment.Decode(c)
+	})
+	c.RepeatedMessage(8, func(c *picobuf.Decoder) {
+		x := new(Tag)
+		c.Loop(x.Decode)
+		m.Tags = append(m.Tags, x)
+	})
 }
 
 type Record struct {
@@ -281,6 +551,13 @@ type Record struct {
 	Timestamp             *Timestamp `json:"timestamp,omitempty"`
 	TimestampCorrectionNs int64      `json:"timestamp_correction_ns,omitempty"`
 	Tags                  []*Tag     `json:"tags,omitempty"`
+	SessionId             uint64     `json:"session_id,omitempty"`
+	Sequence              uint64     `json:"sequence,omitempty"`
+	Severity              Severity   `json:"severity,omitempty"`
+	Version               uint32     `json:"version,omitempty"`
+	Name                  string     `json:"name,omitempty"`
+	Scope                 []string   `json:"scope,omitempty"`
+	Received              *Timestamp `json:"received,omitempty"`
 }
 
 func (m *Record) Encode(c *picobuf.Encoder) bool {
@@ -296,6 +573,13 @@ func (m *Record) Encode(c *picobuf.Encoder) bool {
 	for _, x := range m.Tags {
 		c.AlwaysMessage(7, x.Encode)
 	}
+	c.Fixed64(8, &m.SessionId)
+	c.Uint64(9, &m.Sequence)
+	c.Int32(10, (*int32)(&m.Severity))
+	c.Uint32(11, &m.Version)
+	c.String(12, &m.Name)
+	c.RepeatedString(13, &m.Scope)
+	c.Message(14, m.Received.Encode)
 	return true
 }
 
@@ -319,4 +603,16 @@ func (m *Record) Decode(c *picobuf.Decoder) {
 		c.Loop(x.Decode)
 		m.Tags = append(m.Tags, x)
 	})
+	c.Fixed64(8, &m.SessionId)
+	c.Uint64(9, &m.Sequence)
+	c.Int32(10, (*int32)(&m.Severity))
+	c.Uint32(11, &m.Version)
+	c.String(12, &m.Name)
+	c.RepeatedString(13, &m.Scope)
+	c.Message(14, func(c *picobuf.Decoder) {
+		if m.Received == nil {
+			m.Received = new(Timestamp)
+		}
+		m.Received.Decode(c)
+	})
 }
@@ -3,10 +3,27 @@ package pb
 import (
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
 
+// MaxFragments is the maximum number of fragments of an event.
+const MaxFragments = 1024
+
+// RecordVersion is the version of the records written by eventkitd. See
+// Record.
+const RecordVersion = 2
+
+// EventID returns the unique identifier of an event from its session and
+// sequence, or an empty string when they aren't set.
+func EventID(session, sequence uint64) string {
+	if session == 0 && sequence == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%016x-%x", session, sequence)
+}
+
 func AsTimestamp(t time.Time) *Timestamp {
 	return &Timestamp{
 		Seconds: t.Unix(),
@@ -43,7 +60,78 @@ func (tag *Tag) ValueString() string {
 		return time.Duration(t.DurationNs).String()
 	case *Tag_Timestamp:
 		return t.Timestamp.AsTime().String()
+	case *Tag_List:
+		values := make([]string, 0, len(t.List.GetValues()))
+		for _, value := range t.List.GetValues() {
+			values = append(values, value.ValueString())
+		}
+		return "[" + strings.Join(values, ",") + "]"
+	case *Tag_Map:
+		tags := make([]string, 0, len(t.Map.GetTags()))
+		for _, tag := range t.Map.GetTags() {
+			tags = append(tags, tag.KVString())
+		}
+		return "{" + strings.Join(tags, ",") + "}"
+	case *Tag_Histogram:
+		return (&Tag{Value: &Tag_Map{Map: t.Histogram.AsMap()}}).ValueString()
+	}
+}
+
+// GetValues returns the values of the list, or nil when the list is nil.
+func (m *TagList) GetValues() []*Tag {
+	if m == nil {
+		return nil
+	}
+	return m.Values
+}
+
+// GetTags returns the tags of the map, or nil when the map is nil.
+func (m *TagMap) GetTags() []*Tag {
+	if m == nil {
+		return nil
+	}
+	return m.Tags
+}
+
+// AsMap returns the histogram as nested tags, for the destinations which
+// don't have a histogram type. The buckets are a list of maps with index and
+// count.
+func (m *Histogram) AsMap() *TagMap {
+	if m == nil {
+		return &TagMap{}
+	}
+	buckets := make([]*Tag, 0, len(m.Buckets))
+	for _, b := range m.Buckets {
+		buckets = append(buckets, &Tag{Value: &Tag_Map{Map: &TagMap{Tags: []*Tag{
+			{Key: "index", Value: &Tag_Int64{Int64: int64(b.Index)}},
+			{Key: "count", Value: &Tag_Int64{Int64: int64(b.Count)}},
+		}}}})
+	}
+	return &TagMap{Tags: []*Tag{
+		{Key: "count", Value: &Tag_Int64{Int64: int64(m.Count)}},
+		{Key: "sum", Value: &Tag_Double{Double: m.Sum}},
+		{Key: "min", Value: &Tag_Double{Double: m.Min}},
+		{Key: "max", Value: &Tag_Double{Double: m.Max}},
+		{Key: "scale", Value: &Tag_Int64{Int64: int64(m.Scale)}},
+		{Key: "zero_count", Value: &Tag_Int64{Int64: int64(m.ZeroCount)}},
+		{Key: "buckets", Value: &Tag_List{List: &TagList{Values: buckets}}},
+	}}
+}
+
+// MergeTags returns the tags of an event with the tags of its packet, which
+// the event doesn't have. The tags of the event are not modified.
+func MergeTags(event, packet []*Tag) []*Tag {
+	if len(packet) == 0 {
+		return event
+	}
+	merged := make([]*Tag, len(event), len(event)+len(packet))
+	copy(merged, event)
+	for _, tag := range packet {
+		if !slices.ContainsFunc(event, func(t *Tag) bool { return t.Key == tag.Key }) {
+			merged = append(merged, tag)
+		}
 	}
+	return merged
 }
 
 func (e *Event) TagsString() string {
@@ -0,0 +1,405 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DestinationType describes a layer type of a destination pipeline. See ParseDestination.
+type DestinationType struct {
+	// Name is the prefix of the layer in the configuration, e.g. "udp" in "udp:localhost:9000".
+	Name string
+	// Aliases are alternative names of the layer type.
+	Aliases []string
+	// Params lists the accepted parameters.
+	Params []string
+	// DefaultParam is the parameter which can be given without a key, as the first parameter of the layer.
+	DefaultParam string
+	// Wrapper is true for layers which wrap the destination defined by the preceding layers.
+	Wrapper bool
+	// Create creates the destination of the layer. next creates the destination of the preceding layers, it's nil
+	// when the layer isn't a Wrapper.
+	Create func(ctx context.Context, params LayerParams, next func() (Destination, error)) (Destination, error)
+}
+
+var destinationTypes struct {
+	mu     sync.Mutex
+	byName map[string]*DestinationType
+}
+
+// RegisterDestinationType makes a layer type available for ParseDestination. It's usually called from the init
+// function of the package implementing the destination. It panics if the name or an alias is already registered.
+func RegisterDestinationType(t DestinationType) {
+	destinationTypes.mu.Lock()
+	defer destinationTypes.mu.Unlock()
+	if destinationTypes.byName == nil {
+		destinationTypes.byName = map[string]*DestinationType{}
+	}
+	for _, name := range append([]string{t.Name}, t.Aliases...) {
+		if _, found := destinationTypes.byName[name]; found {
+			panic(fmt.Sprintf("eventkit: destination type %q is already registered", name))
+		}
+		destinationTypes.byName[name] = &t
+	}
+}
+
+// DestinationTypes returns the names of the registered layer types, without the aliases.
+func DestinationTypes() (names []string) {
+	destinationTypes.mu.Lock()
+	defer destinationTypes.mu.Unlock()
+	for name, t := range destinationTypes.byName {
+		if name == t.Name {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func lookupDestinationType(name string) (*DestinationType, bool) {
+	destinationTypes.mu.Lock()
+	defer destinationTypes.mu.Unlock()
+	t, found := destinationTypes.byName[name]
+	return t, found
+}
+
+// LayerParam is a key=value parameter of a layer.
+type LayerParam struct {
+	Key   string
+	Value string
+}
+
+// LayerParams are the parameters of a layer, in the order of the configuration. A key may be repeated.
+type LayerParams []LayerParam
+
+// Get returns the value of the last parameter with the key.
+func (p LayerParams) Get(key string) (value string, found bool) {
+	for _, param := range p {
+		if param.Key == key {
+			value, found = param.Value, true
+		}
+	}
+	return value, found
+}
+
+// Values returns the values of all parameters with the key.
+func (p LayerParams) Values(key string) (values []string) {
+	for _, param := range p {
+		if param.Key == key {
+			values = append(values, param.Value)
+		}
+	}
+	return values
+}
+
+// String returns the value of the parameter, or def when it's missing.
+func (p LayerParams) String(key string, def string) string {
+	if value, found := p.Get(key); found {
+		return value
+	}
+	return def
+}
+
+// Int returns the value of the parameter as a number, or def when it's missing.
+func (p LayerParams) Int(key string, def int) (int, error) {
+	value, found := p.Get(key)
+	if !found {
+		return def, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s parameter should be a number and not %s", key, value)
+	}
+	return v, nil
+}
+
+// Float returns the value of the parameter as a floating point number, or def when it's missing.
+func (p LayerParams) Float(key string, def float64) (float64, error) {
+	value, found := p.Get(key)
+	if !found {
+		return def, nil
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s parameter should be a number and not %s", key, value)
+	}
+	return v, nil
+}
+
+// Bool returns the value of the parameter as a boolean, or def when it's missing.
+func (p LayerParams) Bool(key string, def bool) (bool, error) {
+	value, found := p.Get(key)
+	if !found {
+		return def, nil
+	}
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s parameter should be true or false and not %s", key, value)
+	}
+	return v, nil
+}
+
+// Duration returns the value of the parameter as a duration, or def when it's missing.
+func (p LayerParams) Duration(key string, def time.Duration) (time.Duration, error) {
+	value, found := p.Get(key)
+	if !found {
+		return def, nil
+	}
+	v, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s parameter should be a duration and not %s", key, value)
+	}
+	return v, nil
+}
+
+// Encode returns the parameters in the key=value,... form.
+func (p LayerParams) Encode() string {
+	parts := make([]string, 0, len(p))
+	for _, param := range p {
+		parts = append(parts, param.Key+"="+param.Value)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Layer is a parsed layer of a destination pipeline.
+type Layer struct {
+	Type   *DestinationType
+	Params LayerParams
+}
+
+// String implements fmt.Stringer.
+func (l Layer) String() string {
+	if len(l.Params) == 0 {
+		return l.Type.Name
+	}
+	return l.Type.Name + ":" + l.Params.Encode()
+}
+
+// Pipeline is a parsed destination configuration.
+type Pipeline struct {
+	// Layers starts with the destination receiving the events, followed by the layers wrapping it.
+	Layers []Layer
+}
+
+// String returns the normalized configuration of the pipeline, which can be parsed again.
+func (p *Pipeline) String() string {
+	layers := make([]string, 0, len(p.Layers))
+	for _, l := range p.Layers {
+		layers = append(layers, l.String())
+	}
+	return strings.Join(layers, "|")
+}
+
+// Describe returns a human readable description of the pipeline, one layer per line, starting with the outermost one.
+func (p *Pipeline) Describe() string {
+	var b strings.Builder
+	for i := len(p.Layers) - 1; i >= 0; i-- {
+		l := p.Layers[i]
+		b.WriteString(strings.Repeat("  ", len(p.Layers)-1-i))
+		b.WriteString(l.Type.Name)
+		for _, param := range l.Params {
+			_, _ = fmt.Fprintf(&b, " %s=%s", param.Key, param.Value)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Create creates the destination of the pipeline. Wrapper layers may create the preceding layers more than once (for
+// example, parallel creates them for each worker).
+func (p *Pipeline) Create(ctx context.Context) (Destination, error) {
+	var next func() (Destination, error)
+	for _, l := range p.Layers {
+		prev := next
+		next = func() (Destination, error) {
+			dest, err := l.Type.Create(ctx, l.Params, prev)
+			if err != nil {
+				return nil, fmt.Errorf("%s destination: %w", l.Type.Name, err)
+			}
+			return dest, nil
+		}
+	}
+	if next == nil {
+		return nil, fmt.Errorf("no eventkit destination is defined")
+	}
+	return next()
+}
+
+// ParseDestination parses a destination configuration. The configuration is a list of layers separated by |, each
+// in the form type:param=value,... The first layer is the destination receiving the events, the following layers
+// wrap it. A single host:port is a shorthand for udp:host:port.
+//
+// Example configurations:
+//
+//	127.0.0.1:1234
+//	udp:127.0.0.1:1234,application=satellite
+//	udp:10.0.0.1:1234,addr=10.0.0.2:1234,policy=failover
+//	tcp:127.0.0.1:1234,timeout=5s
+//	stdout:logfmt
+//	file:/var/lib/eventkit,application=satellite|batch:queueSize=1000
+//	bigquery:appName=...,project=...,dataset=...|batch:queueSize=111,batchSize=500,flushInterval=10s
+//	bigquery:appName=...,project=...,dataset=...|batch:queueSize=111|parallel:workers=10
+//
+// The layer types are registered with RegisterDestinationType. Package eventkit registers udp and tcp, the destination
+// and bigquery packages register their own types when they are imported. Unknown layer types and parameters are
+// rejected.
+func ParseDestination(config string) (*Pipeline, error) {
+	config = strings.TrimSpace(config)
+	if _, _, err := net.SplitHostPort(config); err == nil && !strings.ContainsAny(config, "|,=") {
+		if _, found := lookupDestinationType(strings.SplitN(config, ":", 2)[0]); !found {
+			config = "udp:" + config
+		}
+	}
+
+	p := &Pipeline{}
+	for layer := range strings.SplitSeq(config, "|") {
+		layer = strings.TrimSpace(layer)
+		if layer == "" {
+			continue
+		}
+		typeName, params, _ := strings.Cut(layer, ":")
+		t, found := lookupDestinationType(typeName)
+		if !found {
+			return nil, fmt.Errorf("unknown eventkit destination type %q, please use one of %s",
+				typeName, strings.Join(DestinationTypes(), "/"))
+		}
+		if t.Wrapper != (len(p.Layers) > 0) {
+			if t.Wrapper {
+				return nil, fmt.Errorf("%s layer requires a destination to wrap", t.Name)
+			}
+			return nil, fmt.Errorf("%s destination should be the first layer", t.Name)
+		}
+		parsed, err := parseLayerParams(t, params)
+		if err != nil {
+			return nil, err
+		}
+		p.Layers = append(p.Layers, Layer{Type: t, Params: parsed})
+	}
+	if len(p.Layers) == 0 {
+		return nil, fmt.Errorf("no eventkit destination is defined")
+	}
+	return p, nil
+}
+
+func parseLayerParams(t *DestinationType, params string) (parsed LayerParams, err error) {
+	if params == "" {
+		return nil, nil
+	}
+	for i, param := range strings.Split(params, ",") {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			if i > 0 || t.DefaultParam == "" {
+				return nil, fmt.Errorf("parameters of %s destination should be defined in param=value format, not %q",
+					t.Name, param)
+			}
+			key, value = t.DefaultParam, param
+		}
+		if !slices.Contains(t.Params, key) {
+			return nil, fmt.Errorf("unknown parameter %q for %s destination, please use %s",
+				key, t.Name, strings.Join(t.Params, "/"))
+		}
+		parsed = append(parsed, LayerParam{Key: key, Value: value})
+	}
+	return parsed, nil
+}
+
+// CreateDestination parses the configuration with ParseDestination and creates the destination.
+func CreateDestination(ctx context.Context, config string) (Destination, error) {
+	p, err := ParseDestination(config)
+	if err != nil {
+		return nil, err
+	}
+	return p.Create(ctx)
+}
+
+func init() {
+	RegisterDestinationType(DestinationType{
+		Name:         "udp",
+		Params:       []string{"addr", "policy", "application", "version", "instance", "shards", "tag"},
+		DefaultParam: "addr",
+		Create: func(ctx context.Context, params LayerParams, next func() (Destination, error)) (Destination, error) {
+			addrs := params.Values("addr")
+			if len(addrs) == 0 {
+				return nil, fmt.Errorf("addr parameter is required")
+			}
+			application := params.String("application", filepath.Base(os.Args[0]))
+			instance, found := params.Get("instance")
+			if !found {
+				instance, _ = os.Hostname()
+			}
+			client := NewUDPClient(application, params.String("version", ""), instance, addrs[0])
+			if len(addrs) > 1 {
+				client.Collectors = addrs
+			}
+			policy, err := ParseCollectorPolicy(params.String("policy", CollectorsAll.String()))
+			if err != nil {
+				return nil, err
+			}
+			client.CollectorPolicy = policy
+			if params.String("shards", "") == "auto" {
+				client.Shards = AutoShards
+			} else {
+				shards, err := params.Int("shards", 0)
+				if err != nil {
+					return nil, err
+				}
+				client.Shards = shards
+			}
+			client.Tags, err = instanceTags(params)
+			if err != nil {
+				return nil, err
+			}
+			return client, nil
+		},
+	})
+	RegisterDestinationType(DestinationType{
+		Name:         "tcp",
+		Params:       []string{"addr", "application", "version", "instance", "tag", "timeout"},
+		DefaultParam: "addr",
+		Create: func(ctx context.Context, params LayerParams, next func() (Destination, error)) (Destination, error) {
+			addr, found := params.Get("addr")
+			if !found {
+				return nil, fmt.Errorf("addr parameter is required")
+			}
+			application := params.String("application", filepath.Base(os.Args[0]))
+			instance, found := params.Get("instance")
+			if !found {
+				instance, _ = os.Hostname()
+			}
+			client := NewTCPClient(application, params.String("version", ""), instance, addr)
+			timeout, err := params.Duration("timeout", client.Timeout)
+			if err != nil {
+				return nil, err
+			}
+			client.Timeout = timeout
+			client.Tags, err = instanceTags(params)
+			if err != nil {
+				return nil, err
+			}
+			return client, nil
+		},
+	})
+}
+
+// instanceTags parses the tag=key=value parameters of the client layers.
+func instanceTags(params LayerParams) (tags []Tag, err error) {
+	for _, tag := range params.Values("tag") {
+		key, value, found := strings.Cut(tag, "=")
+		if !found {
+			return nil, fmt.Errorf("tag parameter should be defined in tag=key=value format, not %q", tag)
+		}
+		tags = append(tags, String(key, value))
+	}
+	return tags, nil
+}
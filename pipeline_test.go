@@ -0,0 +1,135 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type wrappingDestination struct {
+	Destination
+	params LayerParams
+}
+
+func init() {
+	RegisterDestinationType(DestinationType{
+		Name:         "test-sink",
+		Params:       []string{"name"},
+		DefaultParam: "name",
+		Create: func(ctx context.Context, params LayerParams, next func() (Destination, error)) (Destination, error) {
+			return &recordingDestination{}, nil
+		},
+	})
+	RegisterDestinationType(DestinationType{
+		Name:    "test-wrap",
+		Aliases: []string{"tw"},
+		Params:  []string{"key"},
+		Wrapper: true,
+		Create: func(ctx context.Context, params LayerParams, next func() (Destination, error)) (Destination, error) {
+			target, err := next()
+			if err != nil {
+				return nil, err
+			}
+			return &wrappingDestination{Destination: target, params: params}, nil
+		},
+	})
+}
+
+func TestParseDestination(t *testing.T) {
+	p, err := ParseDestination(" test-sink:foo | tw:key=a,key=b ")
+	requireNoError(t, err)
+	requireEqual(t, p.String(), "test-sink:name=foo|test-wrap:key=a,key=b")
+	requireEqual(t, p.Describe(), "test-wrap key=a key=b\n  test-sink name=foo\n")
+
+	dest, err := p.Create(context.Background())
+	requireNoError(t, err)
+	wrapper, ok := dest.(*wrappingDestination)
+	requireEqual(t, ok, true)
+	value, _ := wrapper.params.Get("key")
+	requireEqual(t, value, "b")
+	_, ok = wrapper.Destination.(*recordingDestination)
+	requireEqual(t, ok, true)
+
+	p, err = ParseDestination("localhost:9000")
+	requireNoError(t, err)
+	requireEqual(t, p.String(), "udp:addr=localhost:9000")
+
+	p, err = ParseDestination("udp:localhost:9000,application=app")
+	requireNoError(t, err)
+	requireEqual(t, p.String(), "udp:addr=localhost:9000,application=app")
+
+	dest, err = CreateDestination(context.Background(), "udp:localhost:9000,shards=auto")
+	requireNoError(t, err)
+	requireEqual(t, dest.(*UDPClient).Shards, AutoShards)
+
+	dest, err = CreateDestination(context.Background(), "udp:localhost:9000,addr=localhost:9001,policy=failover")
+	requireNoError(t, err)
+	requireEqual(t, dest.(*UDPClient).Collectors, []string{"localhost:9000", "localhost:9001"})
+	requireEqual(t, dest.(*UDPClient).CollectorPolicy, CollectorsFailover)
+
+	dest, err = CreateDestination(context.Background(), "udp:localhost:9000,tag=region=eu-west,tag=commit=abc")
+	requireNoError(t, err)
+	tags := dest.(*UDPClient).Tags
+	requireEqual(t, len(tags), 2)
+	requireEqual(t, tags[0].KVString(), "region=eu-west")
+	requireEqual(t, tags[1].KVString(), "commit=abc")
+	_, err = CreateDestination(context.Background(), "udp:localhost:9000,tag=region")
+	requireEqual(t, err != nil, true)
+
+	dest, err = CreateDestination(context.Background(), "tcp:localhost:9000,timeout=5s,tag=region=eu-west")
+	requireNoError(t, err)
+	requireEqual(t, dest.(*TCPClient).Addr, "localhost:9000")
+	requireEqual(t, dest.(*TCPClient).Timeout, 5*time.Second)
+	requireEqual(t, len(dest.(*TCPClient).Tags), 1)
+
+	for _, config := range []string{
+		"",
+		"unknown:foo=bar",
+		"test-sink:other=1",
+		"test-sink|test-wrap:key",
+		"test-wrap:key=a",
+		"test-sink|test-sink",
+	} {
+		_, err := ParseDestination(config)
+		if err == nil {
+			t.Fatalf("expected an error for %q", config)
+		}
+	}
+}
+
+func TestLayerParams(t *testing.T) {
+	params := LayerParams{{Key: "n", Value: "10"}, {Key: "d", Value: "1s"}, {Key: "f", Value: "0.5"}, {Key: "b", Value: "true"}, {Key: "bad", Value: "x"}}
+
+	n, err := params.Int("n", 1)
+	requireNoError(t, err)
+	requireEqual(t, n, 10)
+	n, err = params.Int("missing", 1)
+	requireNoError(t, err)
+	requireEqual(t, n, 1)
+	_, err = params.Int("bad", 1)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	f, err := params.Float("f", 1)
+	requireNoError(t, err)
+	requireEqual(t, f, 0.5)
+
+	b, err := params.Bool("b", false)
+	requireNoError(t, err)
+	requireEqual(t, b, true)
+	_, err = params.Bool("bad", false)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	d, err := params.Duration("d", 0)
+	requireNoError(t, err)
+	requireEqual(t, d.String(), "1s")
+
+	requireEqual(t, params.String("missing", "def"), "def")
+	requireEqual(t, params.Encode(), "n=10,d=1s,f=0.5,b=true,bad=x")
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Priority is the importance of an event. Destinations which queue the
+// events keep a separate queue for every priority, so a burst of less
+// important events doesn't cause the more important ones to be dropped.
+// The zero value is PriorityNormal.
+type Priority int8
+
+const (
+	// PriorityDebug is for verbose events, which are dropped first.
+	PriorityDebug Priority = -1
+	// PriorityNormal is the default priority.
+	PriorityNormal Priority = 0
+	// PriorityCritical is for operational events, like alerts.
+	PriorityCritical Priority = 1
+)
+
+// NumPriorities is the number of the priority classes.
+const NumPriorities = 3
+
+// Priorities lists the priorities from the highest to the lowest. The
+// position of a priority in it is its Class.
+var Priorities = [NumPriorities]Priority{PriorityCritical, PriorityNormal, PriorityDebug}
+
+// Class returns the index of the priority in Priorities, 0 being the
+// highest. Priorities out of the known range are clamped.
+func (p Priority) Class() int {
+	return int(PriorityCritical - min(max(p, PriorityDebug), PriorityCritical))
+}
+
+// String implements fmt.Stringer.
+func (p Priority) String() string {
+	switch p {
+	case PriorityDebug:
+		return "debug"
+	case PriorityNormal:
+		return "normal"
+	case PriorityCritical:
+		return "critical"
+	}
+	return fmt.Sprintf("Priority(%d)", int8(p))
+}
+
+// ParsePriority parses the name of a priority, as returned by String.
+func ParsePriority(name string) (Priority, error) {
+	for _, p := range Priorities {
+		if strings.EqualFold(name, p.String()) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown event priority %q, please use critical/normal/debug", name)
+}
+
+// PriorityWeights configures how the queues of the priority classes are
+// drained, with a weight for every class in the order of Priorities.
+//
+// The zero value drains them by strict priority: events are taken from a
+// queue only when the queues of the higher priorities are empty. Otherwise
+// the non-empty queues are drained in proportion to their weights, so the
+// lower priorities are not starved. A class with zero weight is drained
+// only when the queues with weights are empty.
+type PriorityWeights [NumPriorities]int
+
+// PriorityDrainer selects the queue to take the next event from, based on
+// PriorityWeights. It's not safe for concurrent use.
+type PriorityDrainer struct {
+	weights PriorityWeights
+	current [NumPriorities]int
+}
+
+// NewPriorityDrainer creates a PriorityDrainer with the weights.
+func NewPriorityDrainer(weights PriorityWeights) *PriorityDrainer {
+	return &PriorityDrainer{weights: weights}
+}
+
+// Next returns the class of the queue to drain next, from the classes for
+// which waiting returns true. It returns false when none of them is
+// waiting.
+//
+// The weighted classes are selected with smooth weighted round-robin, so
+// the classes are interleaved instead of drained in bursts.
+func (d *PriorityDrainer) Next(waiting func(class int) bool) (class int, ok bool) {
+	best, total := -1, 0
+	for class := range NumPriorities {
+		weight := d.weights[class]
+		if weight <= 0 || !waiting(class) {
+			continue
+		}
+		d.current[class] += weight
+		total += weight
+		if best < 0 || d.current[class] > d.current[best] {
+			best = class
+		}
+	}
+	if best >= 0 {
+		d.current[best] -= total
+		return best, true
+	}
+
+	for class := range NumPriorities {
+		if d.weights[class] <= 0 && waiting(class) {
+			return class, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"testing"
+)
+
+func TestPriority(t *testing.T) {
+	requireEqual(t, PriorityCritical.Class(), 0)
+	requireEqual(t, PriorityNormal.Class(), 1)
+	requireEqual(t, PriorityDebug.Class(), 2)
+	requireEqual(t, Priority(5).Class(), 0)
+	requireEqual(t, Priority(-5).Class(), 2)
+
+	for _, p := range Priorities {
+		parsed, err := ParsePriority(p.String())
+		requireNoError(t, err)
+		requireEqual(t, parsed, p)
+	}
+	_, err := ParsePriority("urgent")
+	requireEqual(t, err != nil, true)
+
+	r := NewRegistry()
+	dest := &recordingDestination{}
+	r.AddDestination(dest)
+	scope := r.Scope("test").WithPriority(PriorityCritical)
+	scope.Event("a")
+	scope.Subscope("sub").Event("b")
+	r.Scope("test").Event("c")
+	requireEqual(t, dest.events[0].Priority, PriorityCritical)
+	requireEqual(t, dest.events[1].Priority, PriorityCritical)
+	requireEqual(t, dest.events[1].Scope, []string{"test", "sub"})
+	requireEqual(t, dest.events[2].Priority, PriorityNormal)
+}
+
+func TestPriorityDrainer(t *testing.T) {
+	drain := func(weights PriorityWeights, waiting [NumPriorities]int, n int) (order []int) {
+		d := NewPriorityDrainer(weights)
+		for range n {
+			class, ok := d.Next(func(class int) bool { return waiting[class] > 0 })
+			if !ok {
+				break
+			}
+			waiting[class]--
+			order = append(order, class)
+		}
+		return order
+	}
+
+	// strict priority.
+	requireEqual(t, drain(PriorityWeights{}, [NumPriorities]int{2, 1, 2}, 10), []int{0, 0, 1, 2, 2})
+
+	// weighted, interleaved.
+	requireEqual(t, drain(PriorityWeights{2, 1, 0}, [NumPriorities]int{10, 10, 10}, 6), []int{0, 1, 0, 0, 1, 0})
+
+	// zero weight classes are drained only when the others are empty.
+	requireEqual(t, drain(PriorityWeights{2, 1, 0}, [NumPriorities]int{1, 1, 2}, 10), []int{0, 1, 2, 2})
+}
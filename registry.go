@@ -2,6 +2,8 @@ package eventkit
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -10,6 +12,15 @@ type Event struct {
 	Scope     []string
 	Timestamp time.Time
 	Tags      []Tag
+	// Priority decides which events are dropped first when the queues of
+	// the destinations are full. It's not sent to the collector.
+	Priority Priority
+	// ID identifies the event for deduplication, when it's set. See
+	// UniqueIDs.
+	ID EventID
+	// Severity is the level of the event. See Scope.Info and the similar
+	// helpers.
+	Severity Severity
 }
 
 type Destination interface {
@@ -21,8 +32,28 @@ type BatchDestination interface {
 	SubmitBatch(*[]Event)
 }
 
+// Sender is implemented by destinations which can report whether the
+// events were delivered.
+type Sender interface {
+	Destination
+	// Send delivers the events synchronously and returns the error which
+	// prevented the delivery, if any.
+	Send(events ...*Event) error
+}
+
 type Registry struct {
-	dests []Destination
+	// mu serializes modifications of dests. Submit doesn't take it: dests
+	// is copied on every write and swapped atomically.
+	mu    sync.Mutex
+	dests atomic.Pointer[[]*registeredDestination]
+
+	middlewares []Middleware
+}
+
+type registeredDestination struct {
+	handle *DestinationHandle
+	dest   Destination
+	filter Filter
 }
 
 func NewRegistry() *Registry { return &Registry{} }
@@ -34,17 +65,102 @@ func (r *Registry) Scope(name string) *Scope {
 	}
 }
 
-// AddDestination adds an output to the registry. Do not call
-// AddDestination if (*Registry).Submit might be called
-// concurrently. It is expected that AddDestination will be
+// AddDestination adds an output to the registry. It is safe to call
+// concurrently with (*Registry).Submit. The returned handle can be used
+// to remove or replace the destination later.
+//
+// When filters are given, only the events matching all of them are sent
+// to the destination.
+//
+// The registry doesn't run the destination: the caller is responsible
+// for calling Run, and for stopping it after removal.
+func (r *Registry) AddDestination(dest Destination, filters ...Filter) *DestinationHandle {
+	h := &DestinationHandle{r: r}
+	rd := &registeredDestination{handle: h, dest: dest}
+	if len(filters) > 0 {
+		rd.filter = All(filters...)
+	}
+	r.update(func(dests []*registeredDestination) []*registeredDestination {
+		return append(dests, rd)
+	})
+	return h
+}
+
+// update replaces the destination list with the result of fn. fn
+// receives a copy which it is free to modify.
+func (r *Registry) update(fn func([]*registeredDestination) []*registeredDestination) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var dests []*registeredDestination
+	if current := r.dests.Load(); current != nil {
+		dests = append(dests, *current...)
+	}
+	dests = fn(dests)
+	r.dests.Store(&dests)
+}
+
+// DestinationHandle refers to a destination added to a Registry.
+type DestinationHandle struct {
+	r *Registry
+}
+
+// Remove removes the destination from the registry. Events submitted
+// after Remove returns are not sent to it anymore. Calling Remove more
+// than once is a no-op.
+func (h *DestinationHandle) Remove() {
+	h.r.update(func(dests []*registeredDestination) []*registeredDestination {
+		for i, rd := range dests {
+			if rd.handle == h {
+				return append(dests[:i], dests[i+1:]...)
+			}
+		}
+		return dests
+	})
+}
+
+// Replace atomically swaps the destination with dest, keeping its
+// position and filters in the registry. Every event goes either to the
+// old or to the new destination, never both. It returns false if the
+// destination was already removed.
+func (h *DestinationHandle) Replace(dest Destination) (replaced bool) {
+	h.r.update(func(dests []*registeredDestination) []*registeredDestination {
+		for i, rd := range dests {
+			if rd.handle == h {
+				dests[i] = &registeredDestination{handle: h, dest: dest, filter: rd.filter}
+				replaced = true
+				break
+			}
+		}
+		return dests
+	})
+	return replaced
+}
+
+// Use appends middlewares to the chain every event passes through
+// before being submitted to the destinations. Middlewares run in the
+// order they were added. Like AddDestination, Use is expected to be
 // called at initialization time before any events.
-func (r *Registry) AddDestination(dest Destination) {
-	r.dests = append(r.dests, dest)
+func (r *Registry) Use(middlewares ...Middleware) {
+	r.middlewares = append(r.middlewares, middlewares...)
 }
 
-// Submit submits an Event to all added Destinations.
+// Submit submits an Event to all added Destinations whose filters match
+// it, after passing it through the middleware chain.
 func (r *Registry) Submit(e *Event) {
-	for _, dest := range r.dests {
-		dest.Submit(e)
+	for _, mw := range r.middlewares {
+		e = mw(e)
+		if e == nil {
+			return
+		}
+	}
+	dests := r.dests.Load()
+	if dests == nil {
+		return
+	}
+	for _, rd := range *dests {
+		if rd.filter != nil && !rd.filter(e) {
+			continue
+		}
+		rd.dest.Submit(e)
 	}
 }
@@ -0,0 +1,62 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRegistryDestinationHandles(t *testing.T) {
+	r := NewRegistry()
+	scope := r.Scope("test")
+
+	first, second, replacement := &recordingDestination{}, &recordingDestination{}, &recordingDestination{}
+	h1 := r.AddDestination(first)
+	h2 := r.AddDestination(second)
+
+	scope.Event("a")
+	requireEqual(t, len(first.Events()), 1)
+	requireEqual(t, len(second.Events()), 1)
+
+	h1.Remove()
+	h1.Remove()
+	scope.Event("b")
+	requireEqual(t, len(first.Events()), 1)
+	requireEqual(t, len(second.Events()), 2)
+
+	requireEqual(t, h2.Replace(replacement), true)
+	scope.Event("c")
+	requireEqual(t, len(second.Events()), 2)
+	requireEqual(t, len(replacement.Events()), 1)
+
+	requireEqual(t, h1.Replace(first), false)
+}
+
+func TestRegistryConcurrentModification(t *testing.T) {
+	r := NewRegistry()
+	scope := r.Scope("test")
+	stable := &recordingDestination{}
+	r.AddDestination(stable)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 1000 {
+			scope.Event("event")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			h := r.AddDestination(&recordingDestination{})
+			h.Replace(&recordingDestination{})
+			h.Remove()
+		}
+	}()
+	wg.Wait()
+
+	requireEqual(t, len(stable.Events()), 1000)
+}
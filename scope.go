@@ -5,19 +5,52 @@ import (
 )
 
 type Scope struct {
-	r    *Registry
-	name []string
+	r        *Registry
+	name     []string
+	priority Priority
 }
 
 func (s *Scope) Subscope(name string) *Scope {
-	return &Scope{r: s.r, name: append(append([]string(nil), s.name...), name)}
+	return &Scope{r: s.r, name: append(append([]string(nil), s.name...), name), priority: s.priority}
+}
+
+// WithPriority returns a copy of the scope, which submits its events, and
+// the events of its subscopes, with the priority.
+func (s *Scope) WithPriority(priority Priority) *Scope {
+	return &Scope{r: s.r, name: s.name, priority: priority}
 }
 
 func (s *Scope) Event(name string, tags ...Tag) {
+	s.event(SeverityUnspecified, name, tags)
+}
+
+// Debug submits an event with SeverityDebug.
+func (s *Scope) Debug(name string, tags ...Tag) {
+	s.event(SeverityDebug, name, tags)
+}
+
+// Info submits an event with SeverityInfo.
+func (s *Scope) Info(name string, tags ...Tag) {
+	s.event(SeverityInfo, name, tags)
+}
+
+// Warning submits an event with SeverityWarning.
+func (s *Scope) Warning(name string, tags ...Tag) {
+	s.event(SeverityWarning, name, tags)
+}
+
+// Error submits an event with SeverityError.
+func (s *Scope) Error(name string, tags ...Tag) {
+	s.event(SeverityError, name, tags)
+}
+
+func (s *Scope) event(severity Severity, name string, tags []Tag) {
 	s.r.Submit(&Event{
 		Name:      name,
 		Scope:     s.name,
 		Timestamp: time.Now(),
 		Tags:      tags,
+		Priority:  s.priority,
+		Severity:  severity,
 	})
 }
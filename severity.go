@@ -0,0 +1,43 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"fmt"
+	"strings"
+
+	"storj.io/eventkit/pb"
+)
+
+// Severity is the level of an event, like the level of a log line. Unlike
+// Priority, it's sent to the collector. The zero value is
+// SeverityUnspecified.
+type Severity = pb.Severity
+
+const (
+	// SeverityUnspecified is the severity of the events created without one.
+	SeverityUnspecified = pb.Severity_UNSPECIFIED
+	// SeverityDebug is for diagnostic events.
+	SeverityDebug = pb.Severity_DEBUG
+	// SeverityInfo is for informational events.
+	SeverityInfo = pb.Severity_INFO
+	// SeverityWarning is for unexpected events, which are handled.
+	SeverityWarning = pb.Severity_WARNING
+	// SeverityError is for failures.
+	SeverityError = pb.Severity_ERROR
+)
+
+// ParseSeverity parses the name of a severity, case insensitively. "warn"
+// is accepted for SeverityWarning.
+func ParseSeverity(name string) (Severity, error) {
+	if strings.EqualFold(name, "warn") {
+		return SeverityWarning, nil
+	}
+	for _, s := range []Severity{SeverityUnspecified, SeverityDebug, SeverityInfo, SeverityWarning, SeverityError} {
+		if strings.EqualFold(name, s.String()) {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown event severity %q, please use debug/info/warning/error", name)
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"testing"
+	"time"
+
+	"storj.io/eventkit/transport"
+)
+
+func TestParseSeverity(t *testing.T) {
+	for name, expected := range map[string]Severity{
+		"debug":   SeverityDebug,
+		"INFO":    SeverityInfo,
+		"warn":    SeverityWarning,
+		"Warning": SeverityWarning,
+		"error":   SeverityError,
+	} {
+		severity, err := ParseSeverity(name)
+		requireNoError(t, err)
+		requireEqual(t, severity, expected)
+	}
+	_, err := ParseSeverity("fatal")
+	requireEqual(t, err != nil, true)
+}
+
+func TestSeverityIsSent(t *testing.T) {
+	client := NewUDPClient("application", "v1.0.0", "instance", "127.0.0.1:0")
+	packet := client.newShard(client.QueueDepth).newOutgoingPacket()
+	packet.addEvent(&Event{Name: "plain", Timestamp: time.Now()})
+	packet.addEvent(&Event{Name: "failed", Timestamp: time.Now(), Severity: SeverityError})
+	datagrams, _ := packet.finalize()
+
+	parsed, err := transport.ParsePacket(datagrams[0])
+	requireNoError(t, err)
+	requireEqual(t, parsed.Events[0].Severity, SeverityUnspecified)
+	requireEqual(t, parsed.Events[1].Severity, SeverityError)
+}
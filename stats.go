@@ -0,0 +1,176 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats describes the health of a destination. Counters are cumulative
+// since the creation of the destination, and count events unless noted
+// otherwise.
+type Stats struct {
+	// Queued is the number of events waiting to be sent.
+	Queued int64 `json:"queued"`
+	// Sent is the number of events handed over to the next hop.
+	Sent int64 `json:"sent"`
+	// Dropped is the number of events discarded without being sent,
+	// usually because a queue was full.
+	Dropped int64 `json:"dropped"`
+	// DroppedByPriority is the number of dropped events by the name of
+	// their priority, for the destinations which queue the priorities
+	// separately. It's included in Dropped.
+	DroppedByPriority map[string]int64 `json:"dropped_by_priority,omitempty"`
+	// Failed is the number of events which couldn't be delivered.
+	Failed int64 `json:"failed"`
+	// BytesSent is the number of bytes written to the network or disk.
+	BytesSent int64 `json:"bytes_sent"`
+
+	LastError     string    `json:"last_error,omitempty"`
+	LastErrorTime time.Time `json:"last_error_time,omitzero"`
+}
+
+// Add returns the sum of the counters of s and other, keeping the most
+// recent error.
+func (s Stats) Add(other Stats) Stats {
+	sum := Stats{
+		Queued:        s.Queued + other.Queued,
+		Sent:          s.Sent + other.Sent,
+		Dropped:       s.Dropped + other.Dropped,
+		Failed:        s.Failed + other.Failed,
+		BytesSent:     s.BytesSent + other.BytesSent,
+		LastError:     s.LastError,
+		LastErrorTime: s.LastErrorTime,
+	}
+	for _, byPriority := range []map[string]int64{s.DroppedByPriority, other.DroppedByPriority} {
+		for name, n := range byPriority {
+			if sum.DroppedByPriority == nil {
+				sum.DroppedByPriority = map[string]int64{}
+			}
+			sum.DroppedByPriority[name] += n
+		}
+	}
+	if other.LastErrorTime.After(sum.LastErrorTime) {
+		sum.LastError, sum.LastErrorTime = other.LastError, other.LastErrorTime
+	}
+	return sum
+}
+
+// StatsReporter is implemented by destinations which can report their
+// health.
+type StatsReporter interface {
+	Stats() Stats
+}
+
+// StatsCounter is a helper for destinations to keep track of their
+// Stats. The zero value is ready to use, and it's safe for concurrent
+// use.
+type StatsCounter struct {
+	sent, dropped, failed, bytesSent atomic.Int64
+	droppedByPriority                [NumPriorities]atomic.Int64
+
+	mu            sync.Mutex
+	lastError     string
+	lastErrorTime time.Time
+}
+
+// Sent records n sent events.
+func (c *StatsCounter) Sent(n int) { c.sent.Add(int64(n)) }
+
+// Dropped records n dropped events.
+func (c *StatsCounter) Dropped(n int) { c.dropped.Add(int64(n)) }
+
+// DroppedPriority records n dropped events with the priority.
+func (c *StatsCounter) DroppedPriority(priority Priority, n int) {
+	c.dropped.Add(int64(n))
+	c.droppedByPriority[priority.Class()].Add(int64(n))
+}
+
+// BytesSent records n sent bytes.
+func (c *StatsCounter) BytesSent(n int) { c.bytesSent.Add(int64(n)) }
+
+// Failed records n events which couldn't be delivered because of err.
+func (c *StatsCounter) Failed(n int, err error) {
+	c.failed.Add(int64(n))
+	c.Error(err)
+}
+
+// Error records err as the last error, without counting any events.
+func (c *StatsCounter) Error(err error) {
+	if err == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastError = err.Error()
+	c.lastErrorTime = time.Now()
+}
+
+// Snapshot returns the current counters, with the given number of
+// queued events.
+func (c *StatsCounter) Snapshot(queued int) Stats {
+	c.mu.Lock()
+	lastError, lastErrorTime := c.lastError, c.lastErrorTime
+	c.mu.Unlock()
+	stats := Stats{
+		Queued:        int64(queued),
+		Sent:          c.sent.Load(),
+		Dropped:       c.dropped.Load(),
+		Failed:        c.failed.Load(),
+		BytesSent:     c.bytesSent.Load(),
+		LastError:     lastError,
+		LastErrorTime: lastErrorTime,
+	}
+	for class, p := range Priorities {
+		if n := c.droppedByPriority[class].Load(); n > 0 {
+			if stats.DroppedByPriority == nil {
+				stats.DroppedByPriority = map[string]int64{}
+			}
+			stats.DroppedByPriority[p.String()] = n
+		}
+	}
+	return stats
+}
+
+// DestinationStats are the Stats of one destination of a Registry.
+type DestinationStats struct {
+	// Index is the position of the destination in the registry.
+	Index int `json:"index"`
+	// Type is the Go type of the destination.
+	Type string `json:"type"`
+	Stats
+}
+
+// RegistryStats are the Stats of all destinations of a Registry which
+// implement StatsReporter.
+type RegistryStats struct {
+	Total        Stats              `json:"total"`
+	Destinations []DestinationStats `json:"destinations"`
+}
+
+// Stats collects the Stats of the added destinations.
+func (r *Registry) Stats() RegistryStats {
+	var rs RegistryStats
+	dests := r.dests.Load()
+	if dests == nil {
+		return rs
+	}
+	for i, rd := range *dests {
+		reporter, ok := rd.dest.(StatsReporter)
+		if !ok {
+			continue
+		}
+		stats := reporter.Stats()
+		rs.Total = rs.Total.Add(stats)
+		rs.Destinations = append(rs.Destinations, DestinationStats{
+			Index: i,
+			Type:  fmt.Sprintf("%T", rd.dest),
+			Stats: stats,
+		})
+	}
+	return rs
+}
@@ -56,3 +56,59 @@ func Timestamp(key string, val time.Time) Tag {
 		Value: &pb.Tag_Timestamp{Timestamp: pb.AsTimestamp(val)},
 	}
 }
+
+// List creates a tag with a list of values. The keys of the values are
+// ignored, and the values should have the same type.
+func List(key string, values ...Tag) Tag {
+	list := &pb.TagList{Values: make([]*pb.Tag, 0, len(values))}
+	for _, value := range values {
+		list.Values = append(list.Values, &pb.Tag{Value: value.Value})
+	}
+	return &pb.Tag{
+		Key:   key,
+		Value: &pb.Tag_List{List: list},
+	}
+}
+
+// Map creates a tag with nested tags.
+func Map(key string, tags ...Tag) Tag {
+	return &pb.Tag{
+		Key:   key,
+		Value: &pb.Tag_Map{Map: &pb.TagMap{Tags: tags}},
+	}
+}
+
+func Strings(key string, vals []string) Tag {
+	return listOf(key, vals, func(val string) Tag { return String("", val) })
+}
+
+func Int64s(key string, vals []int64) Tag {
+	return listOf(key, vals, func(val int64) Tag { return Int64("", val) })
+}
+
+func Float64s(key string, vals []float64) Tag {
+	return listOf(key, vals, func(val float64) Tag { return Float64("", val) })
+}
+
+func Bools(key string, vals []bool) Tag {
+	return listOf(key, vals, func(val bool) Tag { return Bool("", val) })
+}
+
+func listOf[T any](key string, vals []T, value func(T) Tag) Tag {
+	list := &pb.TagList{Values: make([]*pb.Tag, 0, len(vals))}
+	for _, val := range vals {
+		list.Values = append(list.Values, value(val))
+	}
+	return &pb.Tag{
+		Key:   key,
+		Value: &pb.Tag_List{List: list},
+	}
+}
+
+// HistogramTag creates a tag with a histogram. See Histogram to build one.
+func HistogramTag(key string, val *pb.Histogram) Tag {
+	return &pb.Tag{
+		Key:   key,
+		Value: &pb.Tag_Histogram{Histogram: val},
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"testing"
+	"time"
+
+	"storj.io/picobuf"
+
+	"storj.io/eventkit/pb"
+	"storj.io/eventkit/transport"
+)
+
+func TestStructuredTags(t *testing.T) {
+	event := &pb.Event{
+		Name: "test",
+		Tags: []*pb.Tag{
+			Strings("labels", []string{"a", "b"}),
+			Int64s("sizes", []int64{1, 2, 3}),
+			List("mixed", Float64("ignored", 0.5), Bool("", true)),
+			Map("user", String("id", "u1"), Map("org", Int64("id", 7))),
+			List("empty"),
+		},
+	}
+
+	var strs []string
+	for _, tag := range event.Tags {
+		strs = append(strs, tag.KVString())
+	}
+	requireEqual(t, strs, []string{
+		"labels=[a,b]",
+		"sizes=[1,2,3]",
+		"mixed=[0.5,true]",
+		"user={id=u1,org={id=7}}",
+		"empty=[]",
+	})
+	requireEqual(t, event.Tags[2].Value.(*pb.Tag_List).List.GetValues()[0].Key, "")
+
+	data, err := picobuf.Marshal(event)
+	requireNoError(t, err)
+	var decoded pb.Event
+	requireNoError(t, picobuf.Unmarshal(data, &decoded))
+	requireEqual(t, len(decoded.Tags), len(event.Tags))
+	for i := range event.Tags {
+		requireEqual(t, decoded.Tags[i].KVString(), strs[i])
+	}
+}
+
+func TestInstanceTags(t *testing.T) {
+	client := NewUDPClient("application", "v1.0.0", "instance", "127.0.0.1:0")
+	client.Tags = []Tag{String("region", "eu-west"), String("commit", "abc")}
+	packet := client.newShard(client.QueueDepth).newOutgoingPacket()
+	packet.addEvent(&Event{Name: "a", Timestamp: time.Now(), Tags: []Tag{String("region", "us-east")}})
+	packet.addEvent(&Event{Name: "b", Timestamp: time.Now()})
+	datagrams, _ := packet.finalize()
+
+	parsed, err := transport.ParsePacket(datagrams[0])
+	requireNoError(t, err)
+	requireEqual(t, len(parsed.Tags), 2)
+	requireEqual(t, len(parsed.Events[1].Tags), 0)
+
+	// the tags of the events take precedence.
+	requireEqual(t, (&pb.Event{Tags: pb.MergeTags(parsed.Events[0].Tags, parsed.Tags)}).TagsString(), "region=us-east commit=abc")
+	requireEqual(t, (&pb.Event{Tags: pb.MergeTags(parsed.Events[1].Tags, parsed.Tags)}).TagsString(), "region=eu-west commit=abc")
+}
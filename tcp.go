@@ -0,0 +1,263 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/eventkit/pb"
+	"storj.io/eventkit/utils"
+	"storj.io/picobuf"
+)
+
+const (
+	defaultTCPMaxPacketBytes = 64 * 1024
+	defaultTCPTimeout        = 10 * time.Second
+
+	// maxTCPFrameBytes is the size limit of the compressed packets, as
+	// accepted by the collectors. See transport.MaxFrameSize.
+	maxTCPFrameBytes = 256 * 1024
+)
+
+// errPacketTooLarge is reported for the packets which are larger than
+// maxTCPFrameBytes even after compression.
+var errPacketTooLarge = errors.New("eventkit: packet is too large")
+
+// TCPClient is a Destination sending the events to a collector over TCP, for
+// networks where UDP is blocked or lossy. The packets are the same as the
+// packets of UDPClient, prefixed by their length, so they are not limited by
+// the MTU and never fragmented. When the connection breaks, the events of the
+// packet being written are reported as failed, and the collector is dialed
+// again for the next packet.
+type TCPClient struct {
+	Application string
+	Version     string
+	Instance    string
+	Addr        string
+
+	// QueueDepth limits the queued events.
+	QueueDepth int
+	// MaxPacketBytes limits the uncompressed size of the packets.
+	MaxPacketBytes   int
+	CompressionLevel int
+	FlushInterval    time.Duration
+	// Timeout limits dialing the collector and writing a packet.
+	Timeout time.Duration
+	// Tags are the tags of the instance, sent once in every packet. They
+	// shouldn't be modified after Run is called.
+	Tags []Tag
+
+	initOnce sync.Once
+	queue    chan *Event
+	stats    StatsCounter
+
+	// the state of the sender, used only by Run.
+	conn       net.Conn
+	zlibWriter *zlib.Writer
+	buffer     bytes.Buffer
+}
+
+var _ Destination = &TCPClient{}
+var _ StatsReporter = &TCPClient{}
+
+func NewTCPClient(application, version, instance, addr string) *TCPClient {
+	return &TCPClient{
+		Application: application,
+		Version:     version,
+		Instance:    instance,
+		Addr:        addr,
+
+		QueueDepth:       defaultQueueDepth,
+		MaxPacketBytes:   defaultTCPMaxPacketBytes,
+		CompressionLevel: defaultCompressionLevel,
+		FlushInterval:    defaultFlushInterval,
+		Timeout:          defaultTCPTimeout,
+	}
+}
+
+func (c *TCPClient) init() {
+	c.initOnce.Do(func() {
+		c.queue = make(chan *Event, max(c.QueueDepth, 1))
+	})
+}
+
+// Submit implements Destination. The events which don't fit in the queue
+// are dropped.
+func (c *TCPClient) Submit(events ...*Event) {
+	c.init()
+	for _, ev := range events {
+		select {
+		case c.queue <- ev:
+		default:
+			c.stats.DroppedPriority(ev.Priority, 1)
+		}
+	}
+}
+
+// Run sends the queued events until ctx is canceled. The events queued at
+// that time are still sent.
+func (c *TCPClient) Run(ctx context.Context) {
+	c.init()
+	defer func() {
+		if c.conn != nil {
+			_ = c.conn.Close()
+			c.conn = nil
+		}
+	}()
+
+	ticker := utils.NewJitteredTicker(c.FlushInterval)
+	var background errgroup.Group
+	defer func() { _ = background.Wait() }()
+	background.Go(func() error {
+		ticker.Run(ctx)
+		return nil
+	})
+
+	var enc *picobuf.Encoder
+	var startTime time.Time
+	var events int
+	reset := func() {
+		startTime = time.Now()
+		enc = picobuf.NewEncoder()
+		c.packetHeader(startTime).Encode(enc)
+		events = 0
+	}
+	flush := func() {
+		if events > 0 {
+			_ = c.send(enc.Buffer(), startTime, events)
+		}
+		reset()
+	}
+	add := func(ev *Event) {
+		event := pb.Event{
+			Name:              ev.Name,
+			Scope:             ev.Scope,
+			TimestampOffsetNs: int64(ev.Timestamp.Sub(startTime)),
+			Tags:              ev.Tags,
+			SessionId:         ev.ID.Session,
+			Sequence:          ev.ID.Sequence,
+			Severity:          ev.Severity,
+		}
+		enc.AlwaysMessage(packetEventsField, event.Encode)
+		events++
+		if len(enc.Buffer()) >= c.MaxPacketBytes {
+			flush()
+		}
+	}
+
+	reset()
+	for {
+		select {
+		case ev := <-c.queue:
+			add(ev)
+		case <-ticker.C:
+			flush()
+		case <-ctx.Done():
+			for range len(c.queue) {
+				add(<-c.queue)
+			}
+			flush()
+			return
+		}
+	}
+}
+
+// packetHeader returns the fields of the packets, which are sent before the
+// events.
+func (c *TCPClient) packetHeader(start time.Time) *pb.Packet {
+	return &pb.Packet{
+		Application:        c.Application,
+		ApplicationVersion: c.Version,
+		Instance:           c.Instance,
+		StartTimestamp:     pb.AsTimestamp(start),
+		Tags:               c.Tags,
+	}
+}
+
+// send compresses the encoded packet and writes it to the collector.
+func (c *TCPClient) send(raw []byte, startTime time.Time, events int) (err error) {
+	defer func() {
+		if err != nil {
+			c.stats.Failed(events, err)
+		} else {
+			c.stats.Sent(events)
+		}
+	}()
+
+	trailer, err := picobuf.Marshal(&pb.Packet{
+		SendOffsetNs: int64(time.Since(startTime)),
+	})
+	if err != nil {
+		return err
+	}
+	frame := c.compress(raw, trailer)
+	if len(frame)-4 > maxTCPFrameBytes {
+		return errPacketTooLarge
+	}
+
+	if c.conn == nil {
+		dialer := net.Dialer{Timeout: c.Timeout}
+		c.conn, err = dialer.Dial("tcp", c.Addr)
+		if err != nil {
+			return err
+		}
+	}
+	if c.Timeout > 0 {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(c.Timeout))
+	}
+	n, err := c.conn.Write(frame)
+	if err != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+		return err
+	}
+	c.stats.BytesSent(n)
+	return nil
+}
+
+// compress returns the frame with the compressed parts: the length of the
+// packet as a 4 byte big-endian integer, the magic number and the zlib
+// stream. It's valid until the next call.
+func (c *TCPClient) compress(parts ...[]byte) []byte {
+	c.buffer.Reset()
+	c.buffer.Write(make([]byte, 4))
+	c.buffer.WriteString("EK")
+
+	var err error
+	if c.zlibWriter == nil {
+		c.zlibWriter, err = zlib.NewWriterLevel(&c.buffer, c.CompressionLevel)
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		c.zlibWriter.Reset(&c.buffer)
+	}
+	for _, part := range parts {
+		if _, err = c.zlibWriter.Write(part); err != nil {
+			panic(err)
+		}
+	}
+	if err = c.zlibWriter.Close(); err != nil {
+		panic(err)
+	}
+
+	frame := c.buffer.Bytes()
+	binary.BigEndian.PutUint32(frame, uint32(len(frame)-4))
+	return frame
+}
+
+// Stats implements StatsReporter.
+func (c *TCPClient) Stats() Stats {
+	c.init()
+	return c.stats.Snapshot(len(c.queue))
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eventkit
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"storj.io/eventkit/pb"
+	"storj.io/eventkit/transport"
+)
+
+func TestTCPClient(t *testing.T) {
+	l, err := transport.ListenTCP("127.0.0.1:0")
+	requireNoError(t, err)
+	defer func() { _ = l.Close() }()
+
+	client := NewTCPClient("application", "v1.0.0", "instance", l.LocalAddr().String())
+	client.Tags = []Tag{String("region", "eu-west")}
+	client.QueueDepth = 3
+
+	large := strings.Repeat("x", 4000)
+	client.Submit(&Event{Name: "a", Tags: []Tag{String("large", large)}}, &Event{Name: "b"})
+	client.Submit(&Event{Name: "c"}, &Event{Name: "d"})
+
+	stats := client.Stats()
+	requireEqual(t, stats.Queued, int64(3))
+	requireEqual(t, stats.Dropped, int64(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.Run(ctx)
+
+	payload, source, err := l.Next()
+	requireNoError(t, err)
+	requireEqual(t, source.IP.IsLoopback(), true)
+	packet, err := transport.ParsePacket(payload)
+	requireNoError(t, err)
+
+	requireEqual(t, packet.Application, "application")
+	requireEqual(t, packet.Tags[0].KVString(), "region=eu-west")
+	var names []string
+	for _, event := range packet.Events {
+		names = append(names, event.Name)
+	}
+	requireEqual(t, names, []string{"a", "b", "c"})
+	requireEqual(t, packet.Events[0].Tags[0].Value.(*pb.Tag_String_).String_, []byte(large))
+
+	stats = client.Stats()
+	requireEqual(t, stats.Sent, int64(3))
+	requireEqual(t, stats.BytesSent > 0, true)
+}
+
+func TestTCPClientReconnect(t *testing.T) {
+	l, err := transport.ListenTCP("127.0.0.1:0")
+	requireNoError(t, err)
+	addr := l.LocalAddr().String()
+
+	client := NewTCPClient("application", "v1.0.0", "instance", addr)
+	client.Timeout = time.Second
+	requireNoError(t, client.send(nil, time.Now(), 1))
+	_, _, err = l.Next()
+	requireNoError(t, err)
+	requireNoError(t, l.Close())
+
+	// the broken connection is noticed by one of the next writes.
+	for range 100 {
+		if err := client.send(nil, time.Now(), 1); err != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	requireEqual(t, client.Stats().Failed > 0, true)
+	requireEqual(t, client.conn == nil, true)
+
+	l, err = transport.ListenTCP(addr)
+	requireNoError(t, err)
+	defer func() { _ = l.Close() }()
+	requireNoError(t, client.send(nil, time.Now(), 1))
+	_, _, err = l.Next()
+	requireNoError(t, err)
+	_ = client.conn.Close()
+}
+
+func TestTCPClientPacketTooLarge(t *testing.T) {
+	l, err := transport.ListenTCP("127.0.0.1:0")
+	requireNoError(t, err)
+	defer func() { _ = l.Close() }()
+
+	client := NewTCPClient("application", "v1.0.0", "instance", l.LocalAddr().String())
+	random := make([]byte, maxTCPFrameBytes)
+	_, _ = rand.Read(random)
+	client.Submit(&Event{Name: "large", Tags: []Tag{Bytes("random", random)}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.Run(ctx)
+
+	stats := client.Stats()
+	requireEqual(t, stats.Failed, int64(1))
+	requireEqual(t, stats.LastError, errPacketTooLarge.Error())
+}
@@ -18,6 +18,7 @@ import (
 
 	"storj.io/eventkit"
 	"storj.io/eventkit/bigquery"
+	_ "storj.io/eventkit/otlp" // registers the otlp destination type
 )
 
 var mon = monkit.Package()
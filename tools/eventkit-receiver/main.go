@@ -18,18 +18,33 @@ var (
 	Green  = lipgloss.Color("#01a252")
 	Yellow = lipgloss.Color("#fded02")
 	Red    = lipgloss.Color("#db2d20")
+	Gray   = lipgloss.Color("#808080")
 )
 
+// severityColor returns the color of the severity.
+func severityColor(severity pb.Severity) lipgloss.Color {
+	switch {
+	case severity >= pb.Severity_ERROR:
+		return Red
+	case severity >= pb.Severity_WARNING:
+		return Yellow
+	case severity >= pb.Severity_INFO:
+		return Green
+	}
+	return Gray
+}
+
 func main() {
 	c := cobra.Command{
 		Use:   "eventkit-receiver",
 		Short: "Simple command line evenkit receiver",
 	}
 	listenAddress := c.Flags().StringP("listen", "l", "localhost:9002", "UDP host:port for receiving messages")
+	tcpAddress := c.Flags().StringP("tcp-listen", "t", "", "if set, also receive messages over TCP on this host:port")
 	flagPCAPIface := c.Flags().StringP("pcap-iface", "i", "", "if set, use pcap for udp packets on this interface. must be on linux")
 	metricsAddress := c.Flags().StringP("metrics-addr", "m", "", "HTTP address to listen on with /metrics endpoint")
 	c.RunE = func(cmd *cobra.Command, args []string) error {
-		return run(*listenAddress, *metricsAddress, *flagPCAPIface)
+		return run(*listenAddress, *tcpAddress, *metricsAddress, *flagPCAPIface)
 	}
 
 	err := c.Execute()
@@ -38,15 +53,20 @@ func main() {
 	}
 }
 
-func run(address string, metricsAddress string, iface string) error {
-	listener.ProcessPackages(10, iface, address, metricsAddress, func(ctx context.Context, unparsed *listener.Packet, packet *pb.Packet) error {
+func run(address string, tcpAddress string, metricsAddress string, iface string) error {
+	listener.ProcessPackages(10, iface, address, tcpAddress, metricsAddress, func(ctx context.Context, unparsed *listener.Packet, packet *pb.Packet) error {
 		for _, event := range packet.Events {
 			var tags []string
 			for _, v := range event.Tags {
 				tags = append(tags, fmt.Sprintf("%s=%s", v.Key, lipgloss.NewStyle().Foreground(Yellow).Render(v.ValueString())))
 			}
-			fmt.Printf("%s %s %s %s %s %s\n",
+			var severity string
+			if event.Severity != pb.Severity_UNSPECIFIED {
+				severity = " " + lipgloss.NewStyle().Foreground(severityColor(event.Severity)).Render(event.Severity.String())
+			}
+			fmt.Printf("%s%s %s %s %s %s %s\n",
 				lipgloss.NewStyle().Foreground(Red).Render(unparsed.ReceivedAt.Format(time.RFC3339)),
+				severity,
 				packet.Application,
 				packet.Instance,
 				event.Scope,
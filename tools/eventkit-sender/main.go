@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strings"
 
@@ -10,7 +11,8 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"storj.io/eventkit"
-	"storj.io/eventkit/bigquery"
+	_ "storj.io/eventkit/bigquery" // registers the bigquery and wrapper destination types
+	_ "storj.io/eventkit/otlp"     // registers the otlp destination type
 )
 
 var ek = eventkit.Package()
@@ -22,8 +24,17 @@ func main() {
 		Args:  cobra.MinimumNArgs(1),
 	}
 	name := c.Flags().StringP("name", "n", "test", "Name of the event sending out")
-	dest := c.Flags().StringP("destination", "d", "localhost:9000", "UDP host and port to send out package")
+	dest := c.Flags().StringP("destination", "d", "localhost:9000", "UDP host and port or complex eventkit destination")
+	printOnly := c.Flags().Bool("print", false, "Print the parsed destination pipeline and exit")
 	c.RunE = func(cmd *cobra.Command, args []string) error {
+		if *printOnly {
+			pipeline, err := eventkit.ParseDestination(*dest)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			fmt.Print(pipeline.Describe())
+			return nil
+		}
 		return send(*dest, *name, args)
 	}
 	err := c.Execute()
@@ -36,7 +47,7 @@ func send(dest string, name string, args []string) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	destination, err := bigquery.CreateDestination(ctx, dest)
+	destination, err := eventkit.CreateDestination(ctx, dest)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -38,7 +38,7 @@ func (l *RawLog) render() string {
 			tags = append(tags, fmt.Sprintf("%s=%s", t.Key, t.ValueString()))
 		}
 		d := Colorized(fmt.Sprintf("%15s", e.ReceivedAt.Format("2006-01-02 15:04:05")), Green)
-		fmt.Fprintf(&out, "%s %s %s %s\n", d, strings.Join(e.Event.Scope, "."), Colorized(e.Event.Name, Yellow), strings.Join(tags, " "))
+		fmt.Fprintf(&out, "%s %s %s %s %s\n", d, SeverityColorized(e.Event.Severity), strings.Join(e.Event.Scope, "."), Colorized(e.Event.Name, Yellow), strings.Join(tags, " "))
 	}
 	return out.String()
 }
@@ -40,9 +40,24 @@ func run(address string) error {
 		return err
 	}
 
+	reassembler := transport.NewReassembler(0)
+
 	ctx, cancel := context.WithCancel(context.Background())
 	var eg errgroup.Group
 
+	eg.Go(func() error {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				reassembler.Expire(now)
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+
 	eg.Go(func() error {
 		for {
 			select {
@@ -61,15 +76,24 @@ func run(address string) error {
 			if err != nil {
 				return errs.Wrap(err)
 			}
+			receivedAt := time.Now()
 			packet, err := transport.ParsePacket(payload)
 			if err != nil {
 				return errs.Wrap(err)
 			}
+			packet, err = reassembler.Add(source.String(), packet, receivedAt)
+			if err != nil {
+				return errs.Wrap(err)
+			}
+			if packet == nil {
+				// waiting for the other fragments of the event.
+				continue
+			}
 
 			queue <- &Packet{
 				Packet:     packet,
 				Source:     source,
-				ReceivedAt: time.Now(),
+				ReceivedAt: receivedAt,
 			}
 		}
 	})
@@ -1,21 +1,43 @@
 package main
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	ui "github.com/elek/bubbles"
+
+	"storj.io/eventkit/pb"
 )
 
 var (
 	Green  = lipgloss.Color("#01a252")
 	Yellow = lipgloss.Color("#fded02")
 	Red    = lipgloss.Color("#db2d20")
+	Gray   = lipgloss.Color("#808080")
 )
 
 func Colorized(orig string, color lipgloss.Color) string {
 	return lipgloss.NewStyle().Foreground(color).Render(orig)
 }
 
+// SeverityColorized renders the severity padded to the same width, colored by its level.
+func SeverityColorized(severity pb.Severity) string {
+	if severity == pb.Severity_UNSPECIFIED {
+		return fmt.Sprintf("%-7s", "")
+	}
+	color := Gray
+	switch {
+	case severity >= pb.Severity_ERROR:
+		color = Red
+	case severity >= pb.Severity_WARNING:
+		color = Yellow
+	case severity >= pb.Severity_INFO:
+		color = Green
+	}
+	return Colorized(fmt.Sprintf("%-7s", severity.String()), color)
+}
+
 type MainPane struct {
 	*ui.Tabs
 	events []*Event
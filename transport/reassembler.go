@@ -0,0 +1,147 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+
+	"storj.io/eventkit/pb"
+	"storj.io/picobuf"
+)
+
+var mon = monkit.Package()
+
+const (
+	defaultReassemblyTimeout = 10 * time.Second
+	defaultMaxPendingBytes   = 64 * 1024 * 1024
+)
+
+// Reassembler collects the fragments of events which were split across multiple packets.
+type Reassembler struct {
+	timeout         time.Duration
+	maxPendingBytes int
+
+	mu           sync.Mutex
+	pending      map[fragmentKey]*fragmentedEvent
+	pendingBytes int
+}
+
+type fragmentKey struct {
+	source string
+	id     uint64
+}
+
+type fragmentedEvent struct {
+	first    time.Time
+	parts    [][]byte
+	received int
+	size     int
+}
+
+// NewReassembler creates a Reassembler. Incomplete events are dropped after timeout.
+func NewReassembler(timeout time.Duration) *Reassembler {
+	if timeout <= 0 {
+		timeout = defaultReassemblyTimeout
+	}
+	return &Reassembler{
+		timeout:         timeout,
+		maxPendingBytes: defaultMaxPendingBytes,
+		pending:         map[fragmentKey]*fragmentedEvent{},
+	}
+}
+
+// Add processes a packet received from source. Packets without a fragment are returned as they are. For fragments,
+// it returns nil until all the fragments of the event are received, and then a packet with the reassembled event.
+func (r *Reassembler) Add(source string, packet *pb.Packet, received time.Time) (*pb.Packet, error) {
+	fragment := packet.Fragment
+	if fragment == nil {
+		return packet, nil
+	}
+	if fragment.Count == 0 || fragment.Count > pb.MaxFragments || fragment.Index >= fragment.Count {
+		return nil, fmt.Errorf("invalid fragment %d/%d", fragment.Index, fragment.Count)
+	}
+
+	data, complete, err := r.add(fragmentKey{source: source, id: fragment.Id}, fragment, received)
+	if err != nil || !complete {
+		return nil, err
+	}
+
+	var event pb.Event
+	if err := picobuf.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+	return &pb.Packet{
+		Application:        packet.Application,
+		ApplicationVersion: packet.ApplicationVersion,
+		Instance:           packet.Instance,
+		StartTimestamp:     packet.StartTimestamp,
+		SendOffsetNs:       packet.SendOffsetNs,
+		Events:             []*pb.Event{&event},
+		Tags:               packet.Tags,
+	}, nil
+}
+
+func (r *Reassembler) add(key fragmentKey, fragment *pb.Fragment, received time.Time) (data []byte, complete bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ev, found := r.pending[key]
+	if !found {
+		ev = &fragmentedEvent{first: received, parts: make([][]byte, fragment.Count)}
+		r.pending[key] = ev
+	}
+	if len(ev.parts) != int(fragment.Count) {
+		return nil, false, errors.New("fragment count mismatch")
+	}
+	if ev.parts[fragment.Index] != nil {
+		// duplicate.
+		return nil, false, nil
+	}
+	if r.pendingBytes+len(fragment.Data) > r.maxPendingBytes {
+		r.remove(key, ev)
+		mon.Counter("fragments_dropped").Inc(1)
+		return nil, false, errors.New("too many pending fragments")
+	}
+
+	ev.parts[fragment.Index] = append([]byte{}, fragment.Data...)
+	ev.received++
+	ev.size += len(fragment.Data)
+	r.pendingBytes += len(fragment.Data)
+	if ev.received < len(ev.parts) {
+		return nil, false, nil
+	}
+
+	r.remove(key, ev)
+	data = make([]byte, 0, ev.size)
+	for _, part := range ev.parts {
+		data = append(data, part...)
+	}
+	mon.Counter("fragmented_events").Inc(1)
+	return data, true, nil
+}
+
+// remove must be called with mu held.
+func (r *Reassembler) remove(key fragmentKey, ev *fragmentedEvent) {
+	delete(r.pending, key)
+	r.pendingBytes -= ev.size
+}
+
+// Expire drops the events which are still incomplete after the timeout, and returns their number.
+func (r *Reassembler) Expire(now time.Time) (expired int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, ev := range r.pending {
+		if now.Sub(ev.first) > r.timeout {
+			r.remove(key, ev)
+			expired++
+		}
+	}
+	mon.Counter("fragments_expired").Inc(int64(expired))
+	return expired
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/eventkit/pb"
+	"storj.io/picobuf"
+)
+
+func TestReassembler(t *testing.T) {
+	r := NewReassembler(time.Minute)
+	now := time.Now()
+
+	plain := &pb.Packet{Events: []*pb.Event{{Name: "plain"}}}
+	packet, err := r.Add("a", plain, now)
+	require.NoError(t, err)
+	require.Same(t, plain, packet)
+
+	data, err := picobuf.Marshal(&pb.Event{Name: "fragmented", Scope: []string{"scope"}})
+	require.NoError(t, err)
+	fragment := func(source string, index, count int) *pb.Packet {
+		return &pb.Packet{Application: "app", Tags: []*pb.Tag{{Key: "region"}}, Fragment: &pb.Fragment{
+			Id:    1,
+			Index: uint32(index),
+			Count: uint32(count),
+			Data:  data[index*len(data)/count : (index+1)*len(data)/count],
+		}}
+	}
+
+	packet, err = r.Add("a", fragment("a", 0, 2), now)
+	require.NoError(t, err)
+	require.Nil(t, packet)
+
+	// duplicates are ignored, and other sources don't interfere.
+	packet, err = r.Add("a", fragment("a", 0, 2), now)
+	require.NoError(t, err)
+	require.Nil(t, packet)
+	packet, err = r.Add("b", fragment("b", 1, 2), now)
+	require.NoError(t, err)
+	require.Nil(t, packet)
+
+	_, err = r.Add("a", fragment("a", 0, 3), now)
+	require.Error(t, err)
+	_, err = r.Add("a", fragment("a", 3, 3), now)
+	require.Error(t, err)
+
+	packet, err = r.Add("a", fragment("a", 1, 2), now)
+	require.NoError(t, err)
+	require.Equal(t, "app", packet.Application)
+	require.Equal(t, "region", packet.Tags[0].Key)
+	require.Len(t, packet.Events, 1)
+	require.Equal(t, "fragmented", packet.Events[0].Name)
+	require.Equal(t, []string{"scope"}, packet.Events[0].Scope)
+
+	require.Equal(t, 0, r.Expire(now.Add(time.Second)))
+	require.Equal(t, 1, r.Expire(now.Add(2*time.Minute)))
+}
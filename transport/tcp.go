@@ -0,0 +1,177 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+const (
+	// MaxFrameSize is the largest packet accepted in a TCP frame. It's a
+	// few times the 64 KiB uncompressed packet size of eventkit.TCPClient,
+	// which doesn't send larger packets.
+	MaxFrameSize = 256 * 1024
+
+	// maxTCPConnections limits the connections served at the same time.
+	// The connections over the limit are closed right after they are
+	// accepted.
+	maxTCPConnections = 64
+)
+
+// ReadFrame reads a packet from a TCP stream into buf, which is reset first
+// and grows only as the payload arrives. The packet is prefixed by its length
+// as a 4 byte big-endian integer, and its payload is the same as the payload
+// of a UDP packet. The returned payload is valid until the next call.
+func ReadFrame(r io.Reader, buf *bytes.Buffer) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > MaxFrameSize {
+		return nil, fmt.Errorf("frame of %d bytes is larger than %d bytes", size, MaxFrameSize)
+	}
+	buf.Reset()
+	if _, err := io.CopyN(buf, r, int64(size)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ListenTCP sets up a TCP server that receives packets containing events, in frames read by ReadFrame.
+func ListenTCP(addr string) (*TCPListener, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &TCPListener{
+		ln:     ln,
+		frames: make(chan tcpFrame),
+		closed: make(chan struct{}),
+		conns:  map[net.Conn]struct{}{},
+	}
+	go l.accept()
+	return l, nil
+}
+
+// TCPListener handles accepting the TCP connections and reading the packets from them.
+type TCPListener struct {
+	ln     net.Listener
+	frames chan tcpFrame
+	closed chan struct{}
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+type tcpFrame struct {
+	payload []byte
+	source  *net.TCPAddr
+	err     error
+}
+
+// accept serves the incoming connections until the listener is closed.
+func (l *TCPListener) accept() {
+	for {
+		conn, err := l.ln.Accept()
+		if err != nil {
+			select {
+			case l.frames <- tcpFrame{err: err}:
+			case <-l.closed:
+			}
+			return
+		}
+		if !l.add(conn) {
+			_ = conn.Close()
+			continue
+		}
+		go l.serve(conn)
+	}
+}
+
+// add tracks the connection, so it can be closed with the listener. It returns false when the listener is closed or
+// it already serves maxTCPConnections connections.
+func (l *TCPListener) add(conn net.Conn) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-l.closed:
+		return false
+	default:
+	}
+	if len(l.conns) >= maxTCPConnections {
+		return false
+	}
+	l.conns[conn] = struct{}{}
+	return true
+}
+
+func (l *TCPListener) remove(conn net.Conn) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.conns, conn)
+}
+
+// serve reads the frames of a connection until it's closed. Errors are not reported by Next, the client reconnects.
+func (l *TCPListener) serve(conn net.Conn) {
+	defer func() {
+		l.remove(conn)
+		_ = conn.Close()
+	}()
+
+	source, _ := conn.RemoteAddr().(*net.TCPAddr)
+	r := bufio.NewReader(conn)
+	var buf bytes.Buffer
+	for {
+		payload, err := ReadFrame(r, &buf)
+		if err != nil {
+			return
+		}
+		select {
+		case l.frames <- tcpFrame{payload: bytes.Clone(payload), source: source}:
+		case <-l.closed:
+			return
+		}
+	}
+}
+
+// Next returns the next packet received from any of the connections and it's associated source address. An error is
+// returned only when accepting the connections fails.
+func (l *TCPListener) Next() (payload []byte, source *net.TCPAddr, err error) {
+	select {
+	case frame := <-l.frames:
+		return frame.payload, frame.source, frame.err
+	case <-l.closed:
+		return nil, nil, net.ErrClosed
+	}
+}
+
+func (l *TCPListener) LocalAddr() net.Addr {
+	return l.ln.Addr()
+}
+
+func (l *TCPListener) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-l.closed:
+		return nil
+	default:
+	}
+	close(l.closed)
+	for conn := range l.conns {
+		_ = conn.Close()
+	}
+	return l.ln.Close()
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func frame(size uint32, payload []byte) []byte {
+	return append(binary.BigEndian.AppendUint32(nil, size), payload...)
+}
+
+func TestReadFrame(t *testing.T) {
+	var buf bytes.Buffer
+	r := bytes.NewReader(append(frame(3, []byte("abc")), frame(1, []byte("d"))...))
+	payload, err := ReadFrame(r, &buf)
+	require.NoError(t, err)
+	require.Equal(t, "abc", string(payload))
+	payload, err = ReadFrame(r, &buf)
+	require.NoError(t, err)
+	require.Equal(t, "d", string(payload))
+	_, err = ReadFrame(r, &buf)
+	require.ErrorIs(t, err, io.EOF)
+
+	_, err = ReadFrame(bytes.NewReader(frame(3, []byte("ab"))), &buf)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	// the payload of a frame over the limit isn't buffered.
+	buf = bytes.Buffer{}
+	_, err = ReadFrame(bytes.NewReader(frame(MaxFrameSize+1, nil)), &buf)
+	require.Error(t, err)
+	require.Zero(t, buf.Cap())
+}
+
+func TestTCPListenerConnectionLimit(t *testing.T) {
+	l, err := ListenTCP("127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+
+	var conns []net.Conn
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+	dial := func() net.Conn {
+		conn, err := net.Dial("tcp", l.LocalAddr().String())
+		require.NoError(t, err)
+		conns = append(conns, conn)
+		return conn
+	}
+
+	for range maxTCPConnections {
+		dial()
+	}
+	require.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return len(l.conns) == maxTCPConnections
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the connection over the limit is closed by the listener.
+	over := dial()
+	_ = over.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = over.Read(make([]byte, 1))
+	require.ErrorIs(t, err, io.EOF)
+
+	_, err = conns[0].Write(frame(3, []byte("abc")))
+	require.NoError(t, err)
+	payload, source, err := l.Next()
+	require.NoError(t, err)
+	require.Equal(t, "abc", string(payload))
+	require.True(t, source.IP.IsLoopback())
+}
@@ -26,27 +26,31 @@ func ListenUDP(addr string) (*UDPListener, error) {
 	return &UDPListener{
 		addr: addr,
 		conn: conn,
+		buf:  make([]byte, maxDatagramSize),
 	}, nil
 }
 
+// maxDatagramSize is the largest possible UDP payload.
+const maxDatagramSize = 64 * 1024
+
 // UDPListener handles reading packets from the underlying UDP connection.
 type UDPListener struct {
 	addr string
 	conn *net.UDPConn
+	buf  []byte
 }
 
 // Next returns the next packet from UDP and it's associated source address. Should an error occur, then it is returned.
 // A source address may be returned alongside an error for further reporting in the event of abuse/debugging.
+//
+// It must not be called concurrently.
 func (u *UDPListener) Next() (payload []byte, source *net.UDPAddr, err error) {
-	// TODO: bring back the sync.Pool for these byte buffers
-	var buf [10 * 1024]byte
-
-	n, source, err := u.conn.ReadFromUDP(buf[:])
+	n, source, err := u.conn.ReadFromUDP(u.buf)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return buf[:n], source, err
+	return bytes.Clone(u.buf[:n]), source, err
 }
 
 func (u *UDPListener) LocalAddr() net.Addr {